@@ -5,6 +5,7 @@ package aoc
 import (
 	"bufio"
 	"bytes"
+	"cmp"
 	"flag"
 	"fmt"
 	"go/ast"
@@ -20,12 +21,24 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"golang.org/x/exp/constraints"
 )
 
-var flagDay *string
+var (
+	flagDay      *string
+	flagMemStats *bool
+	flagList     *bool
+	flagComplete *string
+	flagHistory  *bool
+	flagProcs    *int
+	flagSeed     *uint64
+	flagDeterm   *bool
+	flagOffline  *bool
+	flagNoSample *string
+)
 
 var (
 	puzzles      []string
@@ -34,15 +47,55 @@ var (
 	sampleWant   = map[string]string{}
 )
 
+// Year is the Advent of Code year whose inputs are fetched.
+var Year = 2023
+
 var (
-	curDay   int
-	altInput []byte // non-nil to run a sample
+	curDay    int
+	curPuzzle string // func name being run
+	altInput  []byte // non-nil to run a sample
 )
 
 func Main() {
 	flagDay = flag.String("day", "", "func name to run; empty string means latest registered. If it starts with a digit, then \"day\" prefix is assumed.")
+	flagMemStats = flag.Bool("memstats", false, "print allocation and GC statistics after each puzzle run")
+	flagList = flag.Bool("list", false, "list registered puzzle funcs and exit")
+	flagComplete = flag.String("completion", "", "print a shell completion script for -day (bash or zsh) and exit")
+	flagHistory = flag.Bool("history", true, "record run times in "+historyFile+" and compare against the previous run")
+	flagProcs = flag.Int("procs", 0, "if non-zero, set GOMAXPROCS to this")
+	flagSeed = flag.Uint64("seed", 0, "if non-zero, seed for randomized helpers; see Rand")
+	flagDeterm = flag.Bool("deterministic", false, "make helpers iterate maps in a fixed order; see Deterministic")
+	flagOffline = flag.Bool("offline", false, "never fetch inputs from the network; also enabled by a non-empty AOC_OFFLINE environment variable")
+	flagNoSample = flag.String("nosample", "warn", "what to do when a puzzle func has no sample: warn, quiet, or strict (fail)")
+	flag.Usage = usage
 	flag.Parse()
 
+	if *flagProcs > 0 {
+		runtime.GOMAXPROCS(*flagProcs)
+	}
+	if *flagSeed != 0 {
+		SetSeed(*flagSeed)
+	}
+	if *flagDeterm {
+		Deterministic = true
+	}
+
+	switch *flagNoSample {
+	case "warn", "quiet", "strict":
+	default:
+		log.Fatalf("invalid -nosample value %q; want warn, quiet, or strict", *flagNoSample)
+	}
+
+	if *flagComplete != "" {
+		printCompletion(*flagComplete)
+		return
+	}
+	loadSampleFiles()
+	if *flagList {
+		listPuzzles()
+		return
+	}
+
 	funcName := *flagDay
 	if funcName == "" {
 		funcName = puzzles[len(puzzles)-1]
@@ -55,6 +108,10 @@ func Main() {
 	if !ok {
 		log.Fatalf("puzzle func %v not registered", funcName)
 	}
+	curPuzzle = funcName
+	if base, ok := orphanSampleFiles[funcName]; ok {
+		log.Fatalf("%s.sample exists but %s.want doesn't", base, base)
+	}
 	getDay := regexp.MustCompile(`\d+`)
 	if m := getDay.FindStringSubmatch(funcName); m == nil {
 		log.Fatalf("no digits in func name %q from which to extract day number", *flagDay)
@@ -63,18 +120,70 @@ func Main() {
 	}
 	if want, ok := sampleWant[funcName]; ok {
 		altInput = []byte(sampleInput[funcName])
-		got := fmt.Sprint(f())
+		t0 := time.Now()
+		got := strings.TrimRight(formatAnswer(runPuzzle(f)), "\n")
+		took := time.Since(t0)
 		if got != want {
-			fmt.Fprintf(os.Stderr, "❌ for %v sample, got=%v; want %v\n", funcName, got, want)
+			if strings.Contains(got+want, "\n") {
+				fmt.Fprintf(os.Stderr, "❌ for %v sample, got:\n%v\nwant:\n%v\n", funcName, got, want)
+			} else {
+				fmt.Fprintf(os.Stderr, "❌ for %v sample, got=%v; want %v\n", funcName, got, want)
+			}
 			os.Exit(1)
 		}
-		fmt.Fprintf(os.Stderr, "OK sample result.\n")
+		fmt.Fprintf(os.Stderr, "OK sample result (%v).\n", took.Round(time.Microsecond))
 	} else {
-		fmt.Fprintf(os.Stderr, "⚠️ no sample for %v\n", funcName)
+		switch *flagNoSample {
+		case "warn":
+			fmt.Fprintf(os.Stderr, "⚠️ no sample for %v\n", funcName)
+		case "strict":
+			fmt.Fprintf(os.Stderr, "❌ no sample for %v (-nosample=strict)\n", funcName)
+			os.Exit(1)
+		}
 	}
 	altInput = nil
-	v := f()
-	fmt.Println(v)
+	t0 := time.Now()
+	v := runPuzzle(f)
+	took := time.Since(t0)
+	// -memstats forces a GC and samples the heap during the run,
+	// which would skew the recorded history.
+	if *flagHistory && !*flagMemStats {
+		recordRuntime(funcName, took)
+	} else {
+		fmt.Fprintf(os.Stderr, "%v took %v\n", funcName, took.Round(time.Microsecond))
+	}
+	fmt.Println(formatAnswer(v))
+}
+
+func listPuzzles() {
+	var rows [][]any
+	for _, name := range puzzles {
+		want, ok := sampleWant[name]
+		if !ok {
+			want = "(no sample)"
+		}
+		if first, _, multi := strings.Cut(want, "\n"); multi {
+			want = first + "…" // keep the table one row per func
+		}
+		rows = append(rows, []any{name, want})
+	}
+	PrintTable([]string{"FUNC", "SAMPLE WANT"}, rows)
+}
+
+// runPuzzle runs puzzle func f, with any instrumentation requested
+// by flags. If f panics, it reports the panic and exits.
+func runPuzzle(f func() any) any {
+	t0 := time.Now()
+	defer func() {
+		if e := recover(); e != nil {
+			reportPanic(e, time.Since(t0))
+			os.Exit(2)
+		}
+	}()
+	if *flagMemStats {
+		return runWithMemStats(f)
+	}
+	return f()
 }
 
 func ExtractSamples(src []byte) {
@@ -85,27 +194,86 @@ func ExtractSamples(src []byte) {
 	}
 	var lastInput string
 	wantRx := regexp.MustCompile(`(?sm)^\s*want=([^\n]*)(?:\s+(.+\n))?\s*`)
+	// A "sample=@path" line keeps a large or whitespace-sensitive
+	// sample input in its own file. It applies to the func's want=
+	// line wherever it appears in the doc comment.
+	sampleFileRx := regexp.MustCompile(`(?m)^[ \t]*sample=@(\S+)[ \t]*(?:\n|$)`)
 	for _, d := range f.Decls {
 		fd, ok := d.(*ast.FuncDecl)
 		if !ok || fd.Doc == nil {
 			continue
 		}
 		funcName := fd.Name.Name
+		sampleFile := ""
+		if m := sampleFileRx.FindStringSubmatch(fd.Doc.Text()); m != nil {
+			sampleFile = m[1]
+		}
+		sawWant := false
 		for _, c := range fd.Doc.List {
 			text := strings.TrimPrefix(c.Text, "//")
 			if v, ok := strings.CutPrefix(text, "/*"); ok {
 				text = strings.TrimSuffix(v, "*/")
 			}
+			text = sampleFileRx.ReplaceAllString(text, "")
 			if m := wantRx.FindStringSubmatch(text); m != nil {
-				sampleWant[funcName] = m[1]
+				sawWant = true
+				want := m[1]
+				if file, ok := strings.CutPrefix(want, "@"); ok {
+					// A golden file, for large or multi-line output.
+					want = strings.TrimRight(string(MustGet(os.ReadFile(file))), "\n")
+				}
+				sampleWant[funcName] = want
 				in := Or(m[2], lastInput)
+				if sampleFile != "" {
+					in = string(MustGet(os.ReadFile(sampleFile)))
+				}
 				sampleInput[funcName] = in
 				lastInput = in
 			}
 		}
+		if sampleFile != "" && !sawWant {
+			panic(fmt.Sprintf("%s has sample=@%s but no want= line", funcName, sampleFile))
+		}
+	}
+}
+
+// loadSampleFiles loads samples for registered puzzle funcs that
+// don't have one in their doc comments from testdata/NAME.sample and
+// testdata/NAME.want, where NAME is the func name (such as "day5") or
+// its zero-padded form ("day05"). A .sample without a .want is
+// recorded in orphanSampleFiles, to fail only if that func is run.
+func loadSampleFiles() {
+	for _, funcName := range puzzles {
+		if _, ok := sampleWant[funcName]; ok {
+			continue
+		}
+		names := []string{funcName}
+		if m := singleDigitDayRx.FindStringSubmatch(funcName); m != nil {
+			names = append(names, "day0"+m[1]+m[2])
+		}
+		for _, name := range names {
+			base := filepath.Join("testdata", name)
+			in, err := os.ReadFile(base + ".sample")
+			if err != nil {
+				continue
+			}
+			want, err := os.ReadFile(base + ".want")
+			if err != nil {
+				orphanSampleFiles[funcName] = base
+				break
+			}
+			sampleInput[funcName] = string(in)
+			sampleWant[funcName] = strings.TrimRight(string(want), "\n")
+			break
+		}
 	}
 }
 
+var (
+	singleDigitDayRx  = regexp.MustCompile(`^day(\d)(\D.*)?$`) // day5, day5b
+	orphanSampleFiles = map[string]string{}                    // func name -> testdata base path
+)
+
 func funcName(f func() any) string {
 	rv := reflect.ValueOf(f)
 	rf := runtime.FuncForPC(rv.Pointer())
@@ -157,13 +325,75 @@ func AbsDiff[T constraints.Signed](x, y T) T {
 	return v
 }
 
+// AbsInt is the same as Abs. It predates Abs.
 func AbsInt[T constraints.Signed](v T) T {
+	return Abs(v)
+}
+
+// Abs returns the absolute value of v.
+func Abs[T constraints.Signed | constraints.Float](v T) T {
 	if v < 0 {
 		return -v
 	}
 	return v
 }
 
+// Sign returns -1, 0, or 1 depending on whether v is negative, zero,
+// or positive.
+func Sign[T constraints.Signed | constraints.Float](v T) T {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+// Clamp returns v limited to the range [lo, hi].
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	return max(lo, min(v, hi))
+}
+
+// Mod returns a modulo m, in the range [0, |m|), unlike Go's %
+// which takes the sign of a. It's for wrapping around grids and
+// clocks with negative offsets.
+func Mod[T constraints.Integer](a, m T) T {
+	r := a % m
+	if r < 0 {
+		if m < 0 {
+			m = -m
+		}
+		r += m
+	}
+	return r
+}
+
+// FloorDiv returns a/b rounded toward negative infinity.
+func FloorDiv[T constraints.Integer](a, b T) T {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// CeilDiv returns a/b rounded toward positive infinity.
+func CeilDiv[T constraints.Integer](a, b T) T {
+	q := a / b
+	if (a%b != 0) && ((a < 0) == (b < 0)) {
+		q++
+	}
+	return q
+}
+
+// DivMod returns FloorDiv(a, b) and the matching remainder, which
+// has the sign of b, so that q*b + r == a.
+func DivMod[T constraints.Integer](a, b T) (q, r T) {
+	q = FloorDiv(a, b)
+	return q, a - q*b
+}
+
 // MDist returns the manhattan distance between a and b.
 func (a Pt2[T]) MDist(b Pt2[T]) T {
 	return AbsDiff[T](a.X, b.X) + AbsDiff[T](a.Y, b.Y)
@@ -230,6 +460,10 @@ func (p Pt2[T]) South() Pt2[T] { return Pt2[T]{p.X, p.Y + 1} }
 func (p Pt2[T]) West() Pt2[T]  { return Pt2[T]{p.X - 1, p.Y} }
 func (p Pt2[T]) East() Pt2[T]  { return Pt2[T]{p.X + 1, p.Y} }
 
+func (a Pt2[T]) Add(b Pt2[T]) Pt2[T] { return Pt2[T]{a.X + b.X, a.Y + b.Y} }
+func (a Pt2[T]) Sub(b Pt2[T]) Pt2[T] { return Pt2[T]{a.X - b.X, a.Y - b.Y} }
+func (a Pt2[T]) Mul(n T) Pt2[T]      { return Pt2[T]{a.X * n, a.Y * n} }
+
 func (a Pt3[T]) Add(b Pt3[T]) Pt3[T] {
 	return Pt3[T]{a.X + b.X, a.Y + b.Y, a.Z + b.Z}
 }
@@ -276,20 +510,56 @@ func Input() []byte {
 	filename := fmt.Sprintf("%d.input", curDay)
 	f, err := os.ReadFile(filename)
 	if err == nil {
+		if why := badInput(f); why != "" {
+			os.Remove(filename)
+			log.Fatalf("cached %s %s, so it's not a real puzzle input; deleted it. Check ~/keys/aoc.session and run again to refetch.", filename, why)
+		}
 		return f
 	}
+	if offline() {
+		log.Fatalf("offline mode: input %s not cached; fetch it when back online, or save it there by hand", filename)
+	}
 	session := MustGet(os.ReadFile(filepath.Join(os.Getenv("HOME"), "keys", "aoc.session")))
-	req := MustGet(http.NewRequest("GET", fmt.Sprintf("https://adventofcode.com/2023/day/%d/input", curDay), nil))
+	req := MustGet(http.NewRequest("GET", fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", Year, curDay), nil))
 	req.AddCookie(&http.Cookie{Name: "session", Value: strings.TrimSpace(string(session))})
 	res := MustGet(http.DefaultClient.Do(req))
 	if res.StatusCode != 200 {
 		log.Fatalf("bad status: %v", res.Status)
 	}
 	f = MustGet(io.ReadAll(res.Body))
+	if why := badInput(f); why != "" {
+		log.Fatalf("fetched input %s, so it's not a real puzzle input; not caching it. Check ~/keys/aoc.session.", why)
+	}
 	MustDo(os.WriteFile(filename, f, 0644))
 	return f
 }
 
+// badInput returns a non-empty reason if f looks like an error
+// page from adventofcode.com rather than a puzzle input.
+func badInput(f []byte) string {
+	head := f[:min(len(f), 1024)]
+	for _, s := range []string{
+		"Please log in",
+		"Please don't repeatedly request",
+		"before it unlocks",
+		"404 Not Found",
+	} {
+		if bytes.Contains(head, []byte(s)) {
+			return fmt.Sprintf("contains %q", s)
+		}
+	}
+	if t := bytes.TrimSpace(head); bytes.HasPrefix(t, []byte("<!DOCTYPE")) || bytes.HasPrefix(t, []byte("<html")) {
+		return "is an HTML page"
+	}
+	return ""
+}
+
+// offline reports whether network access is disabled by the
+// -offline flag or the AOC_OFFLINE environment variable.
+func offline() bool {
+	return (flagOffline != nil && *flagOffline) || os.Getenv("AOC_OFFLINE") != ""
+}
+
 func Scanner() *bufio.Scanner {
 	return bufio.NewScanner(bytes.NewReader(Input()))
 }
@@ -313,6 +583,39 @@ func MustGet[T any](v T, err error) T {
 	return v
 }
 
+// Assertf panics with the formatted message if cond is false.
+func Assertf(cond bool, format string, args ...any) {
+	if !cond {
+		panic("assertion failed: " + fmt.Sprintf(format, args...))
+	}
+}
+
+// AssertEq panics if got != want, with msg and both values in the
+// panic message.
+func AssertEq[T comparable](got, want T, msg string) {
+	if got != want {
+		panic(fmt.Sprintf("assertion failed: %s: got %v; want %v", msg, got, want))
+	}
+}
+
+// MustGet2 is like MustGet for functions returning two values and an
+// error.
+func MustGet2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
+	if err != nil {
+		panic(err)
+	}
+	return v1, v2
+}
+
+// MustGet3 is like MustGet for functions returning three values and
+// an error.
+func MustGet3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	if err != nil {
+		panic(err)
+	}
+	return v1, v2, v3
+}
+
 // ForLines calls onLine for each line of input.
 // The y value is the row number, starting with 0.
 func ForLines(onLine func(line string)) {
@@ -384,8 +687,31 @@ func GridFromString(s string) Grid {
 	return g
 }
 
-func (g Grid) PosSetWithValue(v rune) map[Pt]bool {
-	s := map[Pt]bool{}
+// GridOfPoints returns a grid covering the bounding box of pts with
+// '#' at each point and '.' elsewhere, for drawing point clouds.
+func GridOfPoints(pts []Pt) Grid {
+	g := Grid{}
+	if len(pts) == 0 {
+		return g
+	}
+	lo, hi := pts[0], pts[0]
+	for _, p := range pts {
+		lo = Pt{min(lo.X, p.X), min(lo.Y, p.Y)}
+		hi = Pt{max(hi.X, p.X), max(hi.Y, p.Y)}
+	}
+	for y := lo.Y; y <= hi.Y; y++ {
+		for x := lo.X; x <= hi.X; x++ {
+			g[Pt{x, y}] = '.'
+		}
+	}
+	for _, p := range pts {
+		g[p] = '#'
+	}
+	return g
+}
+
+func (g Grid) PosSetWithValue(v rune) Set[Pt] {
+	s := Set[Pt]{}
 	for p, r := range g {
 		if r == v {
 			s[p] = true
@@ -421,16 +747,53 @@ func (g Grid) Bounds() (minX, minY, maxX, maxY int) {
 }
 
 func (g Grid) Draw() {
+	g.DrawColors(func(Pt, rune) string { return "" })
+}
+
+// ANSI terminal colors for use with Grid.DrawColors.
+const (
+	ANSIRed     = "\x1b[31m"
+	ANSIGreen   = "\x1b[32m"
+	ANSIYellow  = "\x1b[33m"
+	ANSIBlue    = "\x1b[34m"
+	ANSIMagenta = "\x1b[35m"
+	ANSICyan    = "\x1b[36m"
+	ANSIReverse = "\x1b[7m"
+	ansiReset   = "\x1b[0m"
+)
+
+// DrawHighlight is like Draw but shows the cells in points in
+// reverse video, to check paths, loops, and regions by eye.
+func (g Grid) DrawHighlight(points Set[Pt]) {
+	g.DrawColors(func(p Pt, _ rune) string {
+		if points[p] {
+			return ANSIReverse
+		}
+		return ""
+	})
+}
+
+// DrawColors is like Draw but colors each cell with the ANSI escape
+// sequence returned by color, such as ANSIRed. An empty string
+// leaves the cell uncolored.
+func (g Grid) DrawColors(color func(p Pt, r rune) string) {
 	minX, minY, maxX, maxY := g.Bounds()
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
 	for y := minY; y <= maxY; y++ {
 		for x := minX; x <= maxX; x++ {
-			r := g[Pt{x, y}]
+			p := Pt{x, y}
+			r := g[p]
 			if r == 0 {
 				r = '?'
 			}
-			fmt.Printf("%c", r)
+			if c := color(p, r); c != "" {
+				fmt.Fprintf(w, "%s%c%s", c, r, ansiReset)
+			} else {
+				fmt.Fprintf(w, "%c", r)
+			}
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 }
 
@@ -0,0 +1,95 @@
+package aoc
+
+// Edge is a directed, weighted edge.
+type Edge[N comparable] struct {
+	From, To N
+	Weight   int
+}
+
+// Dists is a matrix of shortest-path distances between pairs of
+// nodes, as returned by FloydWarshall.
+type Dists[N comparable] struct {
+	Nodes []N
+	idx   map[N]int
+	d     [][]int
+	reach [][]bool // whether d[i][j] is a path
+}
+
+// Get returns the shortest distance from a to b and whether b is
+// reachable from a.
+func (d *Dists[N]) Get(a, b N) (dist int, ok bool) {
+	ai, ok1 := d.idx[a]
+	bi, ok2 := d.idx[b]
+	if !ok1 || !ok2 || !d.reach[ai][bi] {
+		return 0, false
+	}
+	return d.d[ai][bi], true
+}
+
+// Dist returns the shortest distance from a to b.
+// It panics if b isn't reachable from a.
+func (d *Dists[N]) Dist(a, b N) int {
+	v, ok := d.Get(a, b)
+	if !ok {
+		panic("no path")
+	}
+	return v
+}
+
+// FloydWarshall returns the shortest distances between every pair of
+// nodes, given directed edges. For undirected graphs, include edges in
+// both directions. Edges may reference nodes not in nodes; they're
+// added. Weights may be negative, but the results are meaningless if
+// the graph has a negative-weight cycle.
+func FloydWarshall[N comparable](nodes []N, edges []Edge[N]) *Dists[N] {
+	d := &Dists[N]{idx: map[N]int{}}
+	add := func(n N) int {
+		if i, ok := d.idx[n]; ok {
+			return i
+		}
+		d.idx[n] = len(d.Nodes)
+		d.Nodes = append(d.Nodes, n)
+		return len(d.Nodes) - 1
+	}
+	for _, n := range nodes {
+		add(n)
+	}
+	for _, e := range edges {
+		add(e.From)
+		add(e.To)
+	}
+	n := len(d.Nodes)
+	d.d = make([][]int, n)
+	d.reach = make([][]bool, n)
+	for i := range d.d {
+		d.d[i] = make([]int, n)
+		d.reach[i] = make([]bool, n)
+		d.reach[i][i] = true
+	}
+	for _, e := range edges {
+		a, b := d.idx[e.From], d.idx[e.To]
+		if !d.reach[a][b] || e.Weight < d.d[a][b] {
+			d.d[a][b] = e.Weight
+			d.reach[a][b] = true
+		}
+	}
+	for k := 0; k < n; k++ {
+		dk, rk := d.d[k], d.reach[k]
+		for i := 0; i < n; i++ {
+			di, ri := d.d[i], d.reach[i]
+			if !ri[k] {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if !rk[j] {
+					continue
+				}
+				if v := di[k] + dk[j]; !ri[j] || v < di[j] {
+					di[j] = v
+					ri[j] = true
+				}
+			}
+		}
+	}
+	return d
+}
@@ -0,0 +1,50 @@
+package aoc
+
+// Arena allocates T values in large chunks rather than one at a time,
+// to cut GC pressure when a search creates millions of small nodes.
+//
+// The zero value is ready to use. An Arena is not safe for concurrent
+// use.
+type Arena[T any] struct {
+	chunks [][]T
+	ci     int // index of current chunk in chunks
+	used   int // elements used in chunks[ci]
+}
+
+const (
+	minArenaChunk = 64
+	maxArenaChunk = 64 << 10
+)
+
+// New returns a pointer to a new zero T.
+func (a *Arena[T]) New() *T {
+	if a.ci == len(a.chunks) || a.used == len(a.chunks[a.ci]) {
+		a.nextChunk()
+	}
+	p := &a.chunks[a.ci][a.used]
+	a.used++
+	return p
+}
+
+func (a *Arena[T]) nextChunk() {
+	if a.ci < len(a.chunks) {
+		a.ci++
+	}
+	if a.ci == len(a.chunks) {
+		size := minArenaChunk
+		if n := len(a.chunks); n > 0 {
+			size = min(2*len(a.chunks[n-1]), maxArenaChunk)
+		}
+		a.chunks = append(a.chunks, make([]T, size))
+	}
+	a.used = 0
+}
+
+// Reset makes all of a's memory available for reuse. Pointers
+// previously returned by New must no longer be used.
+func (a *Arena[T]) Reset() {
+	for i := 0; i <= a.ci && i < len(a.chunks); i++ {
+		clear(a.chunks[i])
+	}
+	a.ci, a.used = 0, 0
+}
@@ -0,0 +1,97 @@
+package aoc
+
+// Automaton steps a cellular automaton over a Grid.
+type Automaton struct {
+	// Rule returns the new value of the cell at p given the previous
+	// generation g. A zero rune means the cell is absent.
+	Rule func(g Grid, p Pt, r rune) rune
+
+	// Grow, if true, also evaluates Rule at absent cells adjacent to
+	// present ones each generation, so the live area can expand
+	// without bound, as in Conway-style puzzles on infinite planes.
+	// Otherwise only the grid's existing cells are evaluated.
+	Grow bool
+
+	cur, next Grid
+	gen       int
+}
+
+// NewAutomaton returns an Automaton starting from a copy of g.
+func NewAutomaton(g Grid, rule func(g Grid, p Pt, r rune) rune) *Automaton {
+	a := &Automaton{Rule: rule, cur: Grid{}, next: Grid{}}
+	for p, r := range g {
+		a.cur[p] = r
+	}
+	return a
+}
+
+// Grid returns the current generation. It's reused by later calls to
+// Step; copy it to keep it.
+func (a *Automaton) Grid() Grid { return a.cur }
+
+// Generation returns the number of Step calls so far.
+func (a *Automaton) Generation() int { return a.gen }
+
+// Step advances one generation and reports whether any cell changed.
+func (a *Automaton) Step() (changed bool) {
+	clear(a.next)
+	eval := func(p Pt) {
+		if _, ok := a.next[p]; ok {
+			return
+		}
+		old := a.cur[p]
+		r := a.Rule(a.cur, p, old)
+		if r != old {
+			changed = true
+		}
+		if r != 0 {
+			a.next[p] = r
+		}
+	}
+	for p := range a.cur {
+		eval(p)
+	}
+	if a.Grow {
+		for p := range a.cur {
+			p.ForNeighbors(func(n Pt) bool {
+				if _, ok := a.cur[n]; !ok {
+					eval(n)
+				}
+				return true
+			})
+		}
+	}
+	a.cur, a.next = a.next, a.cur
+	a.gen++
+	return changed
+}
+
+// StepN advances n generations.
+func (a *Automaton) StepN(n int) {
+	for i := 0; i < n; i++ {
+		a.Step()
+	}
+}
+
+// RunUntilStable steps until a generation changes nothing and returns
+// the number of steps taken, including the final unchanged one.
+func (a *Automaton) RunUntilStable() int {
+	n := 1
+	for a.Step() {
+		n++
+	}
+	return n
+}
+
+// CountNeighbors returns how many of p's eight neighbors in g have
+// value r.
+func (g Grid) CountNeighbors(p Pt, r rune) int {
+	n := 0
+	p.ForNeighbors(func(q Pt) bool {
+		if g[q] == r {
+			n++
+		}
+		return true
+	})
+	return n
+}
@@ -0,0 +1,85 @@
+package aoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// baseDigits returns the base and the value of the first digit for
+// the digit set digits. Digits have consecutive values in the order
+// listed, with '0' (if present) having value zero.
+func baseDigits(digits string) (base, lo int64) {
+	base = int64(len(digits))
+	if base < 2 {
+		panic("need at least two digits")
+	}
+	if i := strings.IndexByte(digits, '0'); i >= 0 {
+		lo = int64(-i)
+	}
+	return base, lo
+}
+
+// ParseBase parses s as a number written with the digit set digits,
+// most significant digit first.
+//
+// Digits have consecutive values in the order listed. If digits
+// contains '0', it has value zero and the digits before it are
+// negative, so "=-012" is the balanced base 5 of the SNAFU puzzle and
+// "0123456789abcdef" is ordinary hex. Without a '0', the first digit
+// is zero.
+//
+// A leading '-' negates the result, unless '-' is itself a digit.
+func ParseBase(s string, digits string) int64 {
+	base, lo := baseDigits(digits)
+	neg := false
+	if strings.IndexByte(digits, '-') < 0 {
+		s, neg = strings.CutPrefix(s, "-")
+	}
+	if s == "" {
+		panic("ParseBase of empty string")
+	}
+	var n int64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(digits, s[i])
+		if d < 0 {
+			panic(fmt.Sprintf("ParseBase: bad digit %q in %q", s[i], s))
+		}
+		n = n*base + int64(d) + lo
+	}
+	if neg {
+		n = -n
+	}
+	return n
+}
+
+// FormatBase formats n with the digit set digits, the inverse of
+// ParseBase.
+func FormatBase(n int64, digits string) string {
+	base, lo := baseDigits(digits)
+	hi := lo + base - 1
+	if n == 0 {
+		if lo > 0 || hi < 0 {
+			panic("digit set can't represent zero")
+		}
+		return string(digits[-lo])
+	}
+	if n < 0 && lo == 0 {
+		return "-" + FormatBase(-n, digits)
+	}
+	var out []byte
+	for n != 0 {
+		d := n % base
+		if d < 0 {
+			d += base
+		}
+		if d > hi {
+			d -= base
+		}
+		out = append(out, digits[d-lo])
+		n = (n - d) / base
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
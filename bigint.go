@@ -0,0 +1,62 @@
+package aoc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// MustBig returns the integer in s, which may be any size, as
+// a *big.Int. It panics if s isn't a base 10 integer.
+func MustBig(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic(fmt.Sprintf("invalid big integer %q", s))
+	}
+	return v
+}
+
+// BigSum returns the sum of vs as a new *big.Int.
+func BigSum(vs ...*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, v := range vs {
+		sum.Add(sum, v)
+	}
+	return sum
+}
+
+// BigProduct returns the product of vs as a new *big.Int, or 1 if vs
+// is empty.
+func BigProduct(vs ...*big.Int) *big.Int {
+	p := big.NewInt(1)
+	for _, v := range vs {
+		p.Mul(p, v)
+	}
+	return p
+}
+
+// formatAnswer formats a puzzle func's result for printing and
+// comparing against sample wants. big values, including non-pointer
+// ones that would otherwise print as structs, print as plain numbers.
+func formatAnswer(v any) string {
+	switch v := v.(type) {
+	case big.Int:
+		return v.String()
+	case big.Rat:
+		return formatAnswer(&v)
+	case *big.Rat:
+		if v == nil {
+			break
+		}
+		if v.IsInt() {
+			return v.Num().String()
+		}
+		return v.RatString()
+	case big.Float:
+		return v.Text('f', -1)
+	case *big.Float:
+		if v != nil {
+			return v.Text('f', -1)
+		}
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"math/bits"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// PopCount returns the number of one bits in v.
+func PopCount[T constraints.Integer](v T) int {
+	u := uint64(v)
+	if size := unsafe.Sizeof(v); size < 8 {
+		u &= 1<<(8*size) - 1 // drop sign extension
+	}
+	return bits.OnesCount64(u)
+}
+
+// PopCountSlice returns the total number of one bits in s.
+func PopCountSlice[T constraints.Integer](s []T) int {
+	n := 0
+	for _, v := range s {
+		n += PopCount(v)
+	}
+	return n
+}
+
+// BitAt reports whether bit i of v is set.
+func BitAt[T constraints.Integer](v T, i int) bool {
+	return v>>i&1 != 0
+}
+
+// SetBit returns v with bit i set to on.
+func SetBit[T constraints.Integer](v T, i int, on bool) T {
+	if on {
+		return v | 1<<i
+	}
+	return v &^ (1 << i)
+}
+
+// EnumerateSubmasks calls f with every submask of mask, from mask
+// itself down to zero, until f returns false.
+func EnumerateSubmasks[T constraints.Integer](mask T, f func(sub T) (keepGoing bool)) {
+	for sub := mask; ; sub = (sub - 1) & mask {
+		if !f(sub) || sub == 0 {
+			return
+		}
+	}
+}
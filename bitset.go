@@ -0,0 +1,125 @@
+package aoc
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Bitset is a dense set of non-negative ints.
+//
+// Get on bits beyond the end reports false, and Set grows the
+// bitset as needed, so the zero value is an empty set ready to use.
+type Bitset []uint64
+
+// NewBitset returns an empty Bitset with room for n bits.
+func NewBitset(n int) Bitset { return make(Bitset, (n+63)/64) }
+
+func (b Bitset) Get(i int) bool {
+	w := i / 64
+	return w < len(b) && b[w]&(1<<(i%64)) != 0
+}
+
+func (b *Bitset) Set(i int) {
+	w := i / 64
+	if w >= len(*b) {
+		*b = append(*b, make(Bitset, w+1-len(*b))...)
+	}
+	(*b)[w] |= 1 << (i % 64)
+}
+
+func (b Bitset) Clear(i int) {
+	if w := i / 64; w < len(b) {
+		b[w] &^= 1 << (i % 64)
+	}
+}
+
+// Count returns the number of set bits.
+func (b Bitset) Count() int {
+	n := 0
+	for _, w := range b {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Equal reports whether b and o have the same bits set.
+func (b Bitset) Equal(o Bitset) bool {
+	if len(b) < len(o) {
+		b, o = o, b
+	}
+	for i, w := range b {
+		if i < len(o) {
+			if w != o[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// And returns a new Bitset of the bits set in both b and o.
+func (b Bitset) And(o Bitset) Bitset {
+	ret := make(Bitset, min(len(b), len(o)))
+	for i := range ret {
+		ret[i] = b[i] & o[i]
+	}
+	return ret
+}
+
+// Or returns a new Bitset of the bits set in either b or o.
+func (b Bitset) Or(o Bitset) Bitset {
+	if len(b) < len(o) {
+		b, o = o, b
+	}
+	ret := slices.Clone(b)
+	for i, w := range o {
+		ret[i] |= w
+	}
+	return ret
+}
+
+// Xor returns a new Bitset of the bits set in exactly one of b and o.
+func (b Bitset) Xor(o Bitset) Bitset {
+	if len(b) < len(o) {
+		b, o = o, b
+	}
+	ret := slices.Clone(b)
+	for i, w := range o {
+		ret[i] ^= w
+	}
+	return ret
+}
+
+// Shift returns a new Bitset with every bit i moved to i+n. Negative
+// n shifts toward zero, dropping bits that fall off the bottom.
+func (b Bitset) Shift(n int) Bitset {
+	if n < 0 {
+		return b.shiftDown(-n)
+	}
+	words, off := n/64, uint(n%64)
+	ret := make(Bitset, len(b)+words+1)
+	for i, w := range b {
+		ret[i+words] |= w << off
+		if off != 0 {
+			ret[i+words+1] |= w >> (64 - off)
+		}
+	}
+	return ret
+}
+
+func (b Bitset) shiftDown(n int) Bitset {
+	words, off := n/64, uint(n%64)
+	if words >= len(b) {
+		return Bitset{}
+	}
+	ret := make(Bitset, len(b)-words)
+	for i := range ret {
+		ret[i] = b[i+words] >> off
+		if off != 0 && i+words+1 < len(b) {
+			ret[i] |= b[i+words+1] << (64 - off)
+		}
+	}
+	return ret
+}
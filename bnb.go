@@ -0,0 +1,34 @@
+package aoc
+
+// BranchAndBound searches the tree of states rooted at start for the
+// state with the maximum score, best-first by optimistic bound.
+//
+// children returns a state's successors, score returns a state's
+// value as a solution (every state is a candidate; return a very low
+// score for states that aren't), and bound returns an upper bound on
+// the score of the state and all its descendants. Subtrees whose
+// bound can't beat the best score found so far are pruned, so the
+// tighter the bound, the less is explored.
+//
+// To minimize, negate the scores and bounds.
+func BranchAndBound[S any](start S, children func(S) []S, score, bound func(S) int) (best int, bestState S) {
+	best, bestState = score(start), start
+	var q PQ[S]
+	q.PushWithPriority(start, -bound(start))
+	for q.Len() > 0 {
+		s, negBound := q.PopMin()
+		if -negBound <= best {
+			// Best-first: nothing left in the queue can do better.
+			break
+		}
+		for _, c := range children(s) {
+			if sc := score(c); sc > best {
+				best, bestState = sc, c
+			}
+			if b := bound(c); b > best {
+				q.PushWithPriority(c, -b)
+			}
+		}
+	}
+	return best, bestState
+}
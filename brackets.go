@@ -0,0 +1,100 @@
+package aoc
+
+import "strings"
+
+// BracketMatch is the result of MatchBrackets.
+type BracketMatch struct {
+	// Corrupt is whether a closing bracket didn't match the most
+	// recent open one. If so, Illegal is that bracket and Pos is its
+	// byte offset.
+	Corrupt bool
+	Illegal rune
+	Pos     int
+
+	// Completion is the closing brackets that would complete s, in
+	// order. It's empty if s is balanced or Corrupt.
+	Completion string
+}
+
+// bracketPairs maps each opening bracket to its closer, and
+// bracketClosers is the reverse.
+var (
+	bracketPairs   = map[rune]rune{'(': ')', '[': ']', '{': '}', '<': '>'}
+	bracketClosers = map[rune]rune{')': '(', ']': '[', '}': '{', '>': '<'}
+)
+
+// MatchBrackets checks that the (), [], {}, and <> brackets in s are
+// properly nested, ignoring other runes. It reports the first
+// mismatched closer, or else what's needed to close any brackets
+// left open.
+func MatchBrackets(s string) BracketMatch {
+	var stack []rune // expected closers
+	for i, r := range s {
+		if c, ok := bracketPairs[r]; ok {
+			stack = append(stack, c)
+			continue
+		}
+		if _, ok := bracketClosers[r]; !ok {
+			continue
+		}
+		if len(stack) == 0 || stack[len(stack)-1] != r {
+			return BracketMatch{Corrupt: true, Illegal: r, Pos: i}
+		}
+		stack = stack[:len(stack)-1]
+	}
+	var sb strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		sb.WriteRune(stack[i])
+	}
+	return BracketMatch{Completion: sb.String()}
+}
+
+// GroupSyntax describes a stream of nested groups for WalkGroups.
+type GroupSyntax struct {
+	Open, Close rune
+
+	// GarbageOpen and GarbageClose, if non-zero, delimit garbage
+	// that's skipped, in which Escape (if non-zero) cancels the rune
+	// after it. Garbage doesn't nest.
+	GarbageOpen, GarbageClose rune
+	Escape                    rune
+}
+
+// StreamSyntax is the "{group}" and "<garbage!>>" stream syntax.
+var StreamSyntax = GroupSyntax{'{', '}', '<', '>', '!'}
+
+// WalkGroups walks the nested groups of s, calling fn with the depth
+// of each group as it opens, starting at 1 for outermost groups. It
+// returns the number of garbage runes skipped, not counting the
+// garbage delimiters or canceled runes.
+func WalkGroups(s string, syn GroupSyntax, fn func(depth int)) (garbage int) {
+	depth := 0
+	inGarbage, escaped := false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case inGarbage && syn.Escape != 0 && r == syn.Escape:
+			escaped = true
+		case inGarbage && r == syn.GarbageClose:
+			inGarbage = false
+		case inGarbage:
+			garbage++
+		case syn.GarbageOpen != 0 && r == syn.GarbageOpen:
+			inGarbage = true
+		case r == syn.Open:
+			depth++
+			fn(depth)
+		case r == syn.Close:
+			depth--
+		}
+	}
+	return garbage
+}
+
+// GroupScore returns the sum of the depths of every group in s, and
+// the garbage count from WalkGroups.
+func GroupScore(s string, syn GroupSyntax) (score, garbage int) {
+	garbage = WalkGroups(s, syn, func(depth int) { score += depth })
+	return score, garbage
+}
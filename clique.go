@@ -0,0 +1,73 @@
+package aoc
+
+// BronKerbosch returns all maximal cliques of the undirected graph
+// adj, using the Bron–Kerbosch algorithm with pivoting.
+//
+// Edges may be listed in adj in one or both directions.
+func BronKerbosch[N comparable](adj map[N][]N) [][]N {
+	nbrs := map[N]Set[N]{}
+	link := func(a, b N) {
+		if nbrs[a] == nil {
+			nbrs[a] = Set[N]{}
+		}
+		nbrs[a].Add(b)
+	}
+	for a := range keysOf(adj) {
+		if nbrs[a] == nil {
+			nbrs[a] = Set[N]{}
+		}
+		for _, b := range adj[a] {
+			if a != b {
+				link(a, b)
+				link(b, a)
+			}
+		}
+	}
+
+	var cliques [][]N
+	var rec func(r []N, p, x Set[N])
+	rec = func(r []N, p, x Set[N]) {
+		if len(p) == 0 && len(x) == 0 {
+			cliques = append(cliques, append([]N(nil), r...))
+			return
+		}
+		// Pivot on the node with the most neighbors in p.
+		var pivot N
+		best := -1
+		for _, s := range []Set[N]{p, x} {
+			for u := range keysOf(s) {
+				n := 0
+				for v := range nbrs[u] {
+					if p[v] {
+						n++
+					}
+				}
+				if n > best {
+					pivot, best = u, n
+				}
+			}
+		}
+		for v := range keysOf(p.Difference(nbrs[pivot])) {
+			rec(append(r, v), p.Intersect(nbrs[v]), x.Intersect(nbrs[v]))
+			p.Delete(v)
+			x.Add(v)
+		}
+	}
+	all := Set[N]{}
+	for n := range nbrs {
+		all.Add(n)
+	}
+	rec(nil, all, Set[N]{})
+	return cliques
+}
+
+// MaxClique returns a largest clique of the undirected graph adj.
+func MaxClique[N comparable](adj map[N][]N) []N {
+	var best []N
+	for _, c := range BronKerbosch(adj) {
+		if len(c) > len(best) {
+			best = c
+		}
+	}
+	return best
+}
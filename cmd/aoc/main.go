@@ -0,0 +1,158 @@
+// The aoc command bootstraps Advent of Code solutions modules that
+// use package github.com/bradfitz/aoc.
+//
+// Usage:
+//
+//	aoc init <year> [dir]
+//
+// init creates dir (default "aoc<year>") containing a go.mod, a
+// main.go wired up to aoc.Main with sample extraction, a stub day1,
+// and a .gitignore for cached inputs.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: aoc init <year> [dir]\n")
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 1 {
+		usage()
+	}
+	switch args[0] {
+	case "init":
+		if len(args) < 2 || len(args) > 3 {
+			usage()
+		}
+		year, err := strconv.Atoi(args[1])
+		if err != nil || year < 2015 {
+			log.Fatalf("bad year %q", args[1])
+		}
+		dir := "aoc" + args[1]
+		if len(args) == 3 {
+			dir = args[2]
+		}
+		initModule(year, dir)
+	default:
+		usage()
+	}
+}
+
+func initModule(year int, dir string) {
+	mod := filepath.Base(dir)
+	if !validModuleName(mod) {
+		log.Fatalf("can't use %q as a module path; pass a dir with a name like aoc%d", mod, year)
+	}
+	// Check everything before writing anything, so a failure doesn't
+	// leave a half-initialized dir behind.
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if _, err := os.Stat(path); err == nil {
+			log.Fatalf("%s already exists; not overwriting", path)
+		}
+	}
+	data := struct {
+		Year   int
+		Module string
+	}{year, mod}
+	contents := make([][]byte, len(files))
+	for i, f := range files {
+		var buf bytes.Buffer
+		if err := template.Must(template.New(f.name).Parse(f.tmpl)).Execute(&buf, data); err != nil {
+			log.Fatal(err)
+		}
+		contents[i] = buf.Bytes()
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	for i, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), contents[i], 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Printf("go mod tidy: %v; run it yourself in %s", err, dir)
+	}
+	fmt.Printf("Created %s. Put your session cookie in ~/keys/aoc.session, then:\n\tcd %s && go run . -day=1\n", dir, dir)
+}
+
+// validModuleName reports whether s works as a single-element module
+// path, as go mod init would accept.
+func validModuleName(s string) bool {
+	if s == "" || s[0] == '.' || s[0] == '-' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-._~", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+var files = []struct{ name, tmpl string }{
+	{"go.mod", `module {{.Module}}
+
+go 1.23
+`},
+	{".gitignore", `*.input
+aoc-history.jsonl
+`},
+	{"main.go", `package main
+
+import (
+	_ "embed"
+
+	"github.com/bradfitz/aoc"
+)
+
+// src is this file, from which aoc.ExtractSamples reads the samples
+// in each puzzle func's doc comment.
+//
+//go:embed main.go
+var src []byte
+
+func main() {
+	aoc.Year = {{.Year}}
+	aoc.ExtractSamples(src)
+	aoc.Add(
+		day1,
+	)
+	aoc.Main()
+}
+
+// day1 solves day 1, part 1.
+//
+// To check it against the puzzle's sample first, add a block comment
+// here whose first line is the want= line with the sample's answer,
+// followed by the sample input.
+func day1() any {
+	sum := 0
+	aoc.ForLines(func(line string) {
+		sum += len(line)
+	})
+	return sum
+}
+`},
+}
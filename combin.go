@@ -0,0 +1,92 @@
+package aoc
+
+import (
+	"math"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Factorial returns n! and whether it fit in an int. Use BigFactorial
+// if ok is false. It panics if n is negative.
+func Factorial(n int) (v int, ok bool) {
+	if n < 0 {
+		panic("Factorial of negative number")
+	}
+	v = 1
+	for i := 2; i <= n; i++ {
+		if v > math.MaxInt/i {
+			return 0, false
+		}
+		v *= i
+	}
+	return v, true
+}
+
+// BigFactorial returns n!. It panics if n is negative.
+func BigFactorial(n int) *big.Int {
+	if n < 0 {
+		panic("BigFactorial of negative number")
+	}
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// Binomial returns n choose k and whether it fit in an int. Use
+// BigBinomial if ok is false. It's 0 if k < 0 or k > n.
+func Binomial(n, k int) (v int, ok bool) {
+	if k < 0 || k > n {
+		return 0, true
+	}
+	k = min(k, n-k)
+	v = 1
+	for i := range k {
+		// v*(n-i) is divisible by i+1, but may overflow on its own,
+		// so cancel the common factor first.
+		num, den := n-i, i+1
+		g := gcd(v, den)
+		v /= g
+		num /= den / g
+		if v > math.MaxInt/num {
+			return 0, false
+		}
+		v *= num
+	}
+	return v, true
+}
+
+// BigBinomial returns n choose k. It's 0 if k < 0 or k > n.
+func BigBinomial(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// gcd returns the non-negative greatest common divisor of a and b.
+func gcd[T constraints.Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		a = -a
+	}
+	return a
+}
+
+// FactorialAny returns n! as an int if it fits, or else as a
+// *big.Int, so it can be returned directly as a puzzle answer.
+func FactorialAny(n int) any {
+	if v, ok := Factorial(n); ok {
+		return v
+	}
+	return BigFactorial(n)
+}
+
+// BinomialAny returns n choose k as an int if it fits, or else as a
+// *big.Int, so it can be returned directly as a puzzle answer.
+func BinomialAny(n, k int) any {
+	if v, ok := Binomial(n, k); ok {
+		return v
+	}
+	return BigBinomial(n, k)
+}
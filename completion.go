@@ -0,0 +1,82 @@
+package aoc
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// hiddenFlags are flag names omitted from -help output.
+var hiddenFlags = map[string]bool{"completion": true}
+
+// usage is flag.Usage, minus hiddenFlags.
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage of %s:\n", os.Args[0])
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	flag.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			fs.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	fs.SetOutput(out)
+	fs.PrintDefaults()
+}
+
+// printCompletion writes the -completion output for kind: "bash" or
+// "zsh" for a shell script to source, or "names" for the registered
+// puzzle func names, which the scripts call back for.
+func printCompletion(kind string) {
+	prog := filepath.Base(os.Args[0])
+	switch kind {
+	case "names":
+		for _, name := range puzzles {
+			fmt.Println(name)
+		}
+	case "zsh":
+		fmt.Println("autoload -U +X bashcompinit && bashcompinit")
+		fallthrough
+	case "bash":
+		fmt.Printf(`_aoc_complete_%[1]s() {
+	local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}" prefix=""
+	case "$cur" in
+	-day=*|--day=*)
+		# "=" isn't in COMP_WORDBREAKS, so the word is "-day=NAME".
+		prefix="${cur%%%%=*}="
+		prev="${prefix%%=}"
+		cur="${cur#*=}"
+		;;
+	=)
+		# Default COMP_WORDBREAKS splits "-day=" into "-day" "=".
+		cur=""
+		;;
+	*)
+		if [[ "$prev" == "=" && $COMP_CWORD -ge 2 ]]; then
+			prev="${COMP_WORDS[COMP_CWORD-2]}"
+		fi
+		;;
+	esac
+	if [[ "$prev" == "-day" || "$prev" == "--day" ]]; then
+		COMPREPLY=( $(compgen -P "$prefix" -W "$("${COMP_WORDS[0]}" -completion=names 2>/dev/null)" -- "$cur") )
+	fi
+}
+complete -F _aoc_complete_%[1]s %[2]s
+`, shellIdent(prog), prog)
+	default:
+		log.Fatalf("unknown -completion kind %q; want bash, zsh, or names", kind)
+	}
+}
+
+// shellIdent returns s with characters not allowed in a shell
+// function name replaced by underscores.
+func shellIdent(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,55 @@
+package aoc
+
+import "slices"
+
+// Compress maps values to small dense indexes: sorted holds the
+// distinct values in increasing order, and rank maps each value to
+// its index in sorted.
+//
+// For area computations over compressed coordinates, remember to
+// include each range's end+1 so the gaps between values get their
+// own index; sorted[i+1]-sorted[i] is then the real width of index i.
+func Compress(values []int) (rank map[int]int, sorted []int) {
+	sorted = slices.Clone(values)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	rank = make(map[int]int, len(sorted))
+	for i, v := range sorted {
+		rank[v] = i
+	}
+	return rank, sorted
+}
+
+// Compressed2D is the result of Compress2D.
+type Compressed2D struct {
+	XRank, YRank map[int]int
+	Xs, Ys       []int // sorted distinct coordinates
+}
+
+// Compress2D compresses the X and Y coordinates of pts independently.
+// See Compress.
+func Compress2D(pts []Pt) *Compressed2D {
+	xs := make([]int, len(pts))
+	ys := make([]int, len(pts))
+	for i, p := range pts {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	c := new(Compressed2D)
+	c.XRank, c.Xs = Compress(xs)
+	c.YRank, c.Ys = Compress(ys)
+	return c
+}
+
+// Pt returns the compressed point for p, whose coordinates must have
+// been among those compressed.
+func (c *Compressed2D) Pt(p Pt) Pt {
+	x, ok1 := c.XRank[p.X]
+	y, ok2 := c.YRank[p.Y]
+	if !ok1 || !ok2 {
+		panic("point not in compressed coordinate set")
+	}
+	return Pt{x, y}
+}
+
+// Orig returns the original point for compressed point p.
+func (c *Compressed2D) Orig(p Pt) Pt { return Pt{c.Xs[p.X], c.Ys[p.Y]} }
@@ -0,0 +1,67 @@
+package aoc
+
+// Counter is a multiset: a count per distinct T value.
+type Counter[T comparable] map[T]int
+
+// Add adds n to v's count. If the count drops to zero or below, v is
+// removed.
+func (c Counter[T]) Add(v T, n int) {
+	n += c[v]
+	if n <= 0 {
+		delete(c, v)
+		return
+	}
+	c[v] = n
+}
+
+// Incr adds one to v's count.
+func (c Counter[T]) Incr(v T) { c[v]++ }
+
+func (c Counter[T]) Count(v T) int { return c[v] }
+
+// Total returns the sum of all counts.
+func (c Counter[T]) Total() int {
+	n := 0
+	for _, cnt := range c {
+		n += cnt
+	}
+	return n
+}
+
+// Most returns the value with the highest count and that count.
+// Ties are broken arbitrarily unless Deterministic is set. It returns
+// the zero T and 0 if c is empty.
+func (c Counter[T]) Most() (v T, n int) {
+	first := true
+	for k := range keysOf(c) {
+		cnt := c[k]
+		if first || cnt > n {
+			v, n, first = k, cnt, false
+		}
+	}
+	return
+}
+
+// Least returns the value with the lowest count and that count.
+// Ties are broken arbitrarily unless Deterministic is set. It returns
+// the zero T and 0 if c is empty.
+func (c Counter[T]) Least() (v T, n int) {
+	first := true
+	for k := range keysOf(c) {
+		cnt := c[k]
+		if first || cnt < n {
+			v, n, first = k, cnt, false
+		}
+	}
+	return
+}
+
+// Frequencies returns how many times each distinct element occurs in
+// s. For letter frequencies, pass []rune(str).
+func Frequencies[T comparable](s []T) Counter[T] {
+	c := Counter[T]{}
+	for _, v := range s {
+		c[v]++
+	}
+	return c
+}
@@ -0,0 +1,26 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+)
+
+// CrossCheck runs n random test cases from gen through both fast and
+// slow, a simple brute-force reference, and panics with the first
+// input on which their answers differ. Answers are compared as Main
+// prints them. gen should make small cases, typically using Rand so
+// failures are reproducible with -seed.
+//
+// It's for finding the off-by-one in an optimized solution: call it
+// from a puzzle func before the real work.
+func CrossCheck[In any](gen func() In, fast, slow func(In) any, n int) {
+	for i := range n {
+		in := gen()
+		want := formatAnswer(slow(in))
+		got := formatAnswer(fast(in))
+		if got != want {
+			panic(fmt.Sprintf("CrossCheck case %d: fast = %v; slow = %v; input:\n%v", i, got, want, in))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "CrossCheck: %d cases OK\n", n)
+}
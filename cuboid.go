@@ -0,0 +1,97 @@
+package aoc
+
+// Cuboid is an axis-aligned box of voxels with inclusive corners.
+type Cuboid struct {
+	Min, Max Vox
+}
+
+// Empty reports whether c contains no voxels.
+func (c Cuboid) Empty() bool {
+	return c.Max.X < c.Min.X || c.Max.Y < c.Min.Y || c.Max.Z < c.Min.Z
+}
+
+// Volume returns the number of voxels in c.
+func (c Cuboid) Volume() int {
+	if c.Empty() {
+		return 0
+	}
+	return (c.Max.X - c.Min.X + 1) * (c.Max.Y - c.Min.Y + 1) * (c.Max.Z - c.Min.Z + 1)
+}
+
+func (c Cuboid) Contains(v Vox) bool {
+	return v.X >= c.Min.X && v.X <= c.Max.X &&
+		v.Y >= c.Min.Y && v.Y <= c.Max.Y &&
+		v.Z >= c.Min.Z && v.Z <= c.Max.Z
+}
+
+// Intersect returns the overlap of c and o, and whether it's
+// non-empty.
+func (c Cuboid) Intersect(o Cuboid) (Cuboid, bool) {
+	x := Cuboid{
+		Vox{max(c.Min.X, o.Min.X), max(c.Min.Y, o.Min.Y), max(c.Min.Z, o.Min.Z)},
+		Vox{min(c.Max.X, o.Max.X), min(c.Max.Y, o.Max.Y), min(c.Max.Z, o.Max.Z)},
+	}
+	return x, !x.Empty()
+}
+
+// CuboidSet is a set of voxels built by adding and removing cuboids,
+// as in the reactor reboot puzzle. It's stored as a signed sum of
+// cuboids (inclusion–exclusion), so it works at coordinate scales
+// where individual voxels can't be stored.
+//
+// The zero value is an empty set ready to use.
+type CuboidSet struct {
+	terms map[Cuboid]int // cuboid -> signed multiplicity
+}
+
+// Add adds all the voxels of c to s.
+func (s *CuboidSet) Add(c Cuboid) { s.apply(c, true) }
+
+// Remove removes all the voxels of c from s.
+func (s *CuboidSet) Remove(c Cuboid) { s.apply(c, false) }
+
+func (s *CuboidSet) apply(c Cuboid, on bool) {
+	if c.Empty() {
+		return
+	}
+	if s.terms == nil {
+		s.terms = map[Cuboid]int{}
+	}
+	// Cancel whatever already covers c, then count c once if on.
+	delta := map[Cuboid]int{}
+	for t, sign := range s.terms {
+		if x, ok := t.Intersect(c); ok {
+			delta[x] -= sign
+		}
+	}
+	if on {
+		delta[c]++
+	}
+	for t, d := range delta {
+		if n := s.terms[t] + d; n != 0 {
+			s.terms[t] = n
+		} else {
+			delete(s.terms, t)
+		}
+	}
+}
+
+// Volume returns the number of voxels in s.
+func (s *CuboidSet) Volume() int {
+	n := 0
+	for t, sign := range s.terms {
+		n += sign * t.Volume()
+	}
+	return n
+}
+
+// Has reports whether v is in s.
+func (s *CuboidSet) Has(v Vox) bool {
+	n := 0
+	for t, sign := range s.terms {
+		if t.Contains(v) {
+			n += sign
+		}
+	}
+	return n > 0
+}
@@ -0,0 +1,95 @@
+package aoc
+
+// Deque is a double-ended queue backed by a growable ring buffer.
+//
+// The zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int // index of front element in buf
+	n    int
+}
+
+func (d *Deque[T]) Len() int { return d.n }
+
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	nb := make([]T, max(8, 2*len(d.buf)))
+	for i := 0; i < d.n; i++ {
+		nb[i] = d.buf[(d.head+i)%len(d.buf)]
+	}
+	d.buf = nb
+	d.head = 0
+}
+
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.n)%len(d.buf)] = v
+	d.n++
+}
+
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the front element.
+// It panics if d is empty.
+func (d *Deque[T]) PopFront() T {
+	if d.n == 0 {
+		panic("PopFront of empty Deque")
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	return v
+}
+
+// PopBack removes and returns the back element.
+// It panics if d is empty.
+func (d *Deque[T]) PopBack() T {
+	if d.n == 0 {
+		panic("PopBack of empty Deque")
+	}
+	var zero T
+	i := (d.head + d.n - 1) % len(d.buf)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return v
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() T { return d.At(0) }
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() T { return d.At(d.n - 1) }
+
+// At returns the i'th element from the front.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("Deque index out of range")
+	}
+	return d.buf[(d.head+i)%len(d.buf)]
+}
+
+// Rotate rotates the deque n steps to the right: the back element
+// moves to the front. Negative n rotates left. This is the marble
+// game's circle.
+func (d *Deque[T]) Rotate(n int) {
+	if d.n <= 1 {
+		return
+	}
+	n %= d.n
+	for ; n > 0; n-- {
+		d.PushFront(d.PopBack())
+	}
+	for ; n < 0; n++ {
+		d.PushBack(d.PopFront())
+	}
+}
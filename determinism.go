@@ -0,0 +1,103 @@
+package aoc
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Deterministic, if true, makes helpers that would otherwise depend
+// on Go's randomized map iteration order (such as Set.Slice,
+// Counter.Most ties, Grid.All, MinCut, and BronKerbosch) use a fixed
+// order instead, so runs are reproducible. It's slower. It's set by
+// the -deterministic flag.
+var Deterministic bool
+
+var (
+	randMu   sync.Mutex
+	randSeed uint64
+	randSet  bool
+	rng      *rand.Rand
+)
+
+// SetSeed seeds the generator returned by Rand. It's set by the
+// -seed flag.
+func SetSeed(seed uint64) {
+	randMu.Lock()
+	defer randMu.Unlock()
+	randSeed, randSet = seed, true
+	rng = nil
+}
+
+// Rand returns the package's shared random number generator, for
+// randomized algorithms. Unless SetSeed was called, it's seeded from
+// the clock on first use and the seed is printed to stderr so the
+// run can be reproduced with -seed.
+//
+// The returned generator is not safe for concurrent use.
+func Rand() *rand.Rand {
+	randMu.Lock()
+	defer randMu.Unlock()
+	if rng == nil {
+		if !randSet {
+			randSeed = uint64(time.Now().UnixNano())
+			fmt.Fprintf(os.Stderr, "random seed %d (reproduce with -seed=%d)\n", randSeed, randSeed)
+		}
+		rng = rand.New(rand.NewPCG(randSeed, randSeed))
+	}
+	return rng
+}
+
+// keysOf returns an iterator over m's keys: ranging over m directly
+// normally, or over a sorted snapshot of the keys if Deterministic is
+// set.
+func keysOf[K comparable, V any](m map[K]V) iter.Seq[K] {
+	if !Deterministic {
+		return maps.Keys(m)
+	}
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sortAny(keys)
+	return slices.Values(keys)
+}
+
+// sortAny sorts s into a fixed order: by value for ints and strings,
+// row-major for Pt, and by fmt.Sprint form otherwise.
+func sortAny[T comparable](s []T) {
+	switch s := any(s).(type) {
+	case []int:
+		slices.Sort(s)
+		return
+	case []string:
+		slices.Sort(s)
+		return
+	case []Pt:
+		slices.SortFunc(s, func(a, b Pt) int {
+			if a.Y != b.Y {
+				return a.Y - b.Y
+			}
+			return a.X - b.X
+		})
+		return
+	}
+	strs := make(map[T]string, len(s))
+	for _, v := range s {
+		strs[v] = fmt.Sprint(v)
+	}
+	slices.SortFunc(s, func(a, b T) int {
+		switch sa, sb := strs[a], strs[b]; {
+		case sa < sb:
+			return -1
+		case sa > sb:
+			return 1
+		}
+		return 0
+	})
+}
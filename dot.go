@@ -0,0 +1,74 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes g in Graphviz DOT format. Edge weights other than 1
+// are written as labels.
+func WriteDOT[N comparable](w io.Writer, g *Graph[N]) error {
+	bw := bufio.NewWriter(w)
+	kind, arrow := "graph", "--"
+	if g.Directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s {\n", kind)
+	id := map[N]int{}
+	for i, n := range g.nodes {
+		id[n] = i
+		fmt.Fprintf(bw, "\t%s;\n", dotQuote(n))
+	}
+	for _, n := range g.nodes {
+		for _, e := range g.out[n] {
+			if !g.Directed && id[e.To] < id[n] {
+				continue // written from the other end
+			}
+			fmt.Fprintf(bw, "\t%s %s %s", dotQuote(n), arrow, dotQuote(e.To))
+			if e.Weight != 1 {
+				fmt.Fprintf(bw, " [label=%d]", e.Weight)
+			}
+			fmt.Fprintf(bw, ";\n")
+		}
+	}
+	fmt.Fprintf(bw, "}\n")
+	return bw.Flush()
+}
+
+func dotQuote(v any) string { return strconv.Quote(fmt.Sprint(v)) }
+
+// GridGraph returns the undirected graph of g's cells for which
+// passable returns true, with edges between orthogonally adjacent
+// passable cells.
+func GridGraph(g Grid, passable func(rune) bool) *Graph[Pt] {
+	gg := new(Graph[Pt])
+	minX, minY, maxX, maxY := g.Bounds()
+	ok := func(p Pt) bool {
+		r, ok := g[p]
+		return ok && passable(r)
+	}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			p := Pt{x, y}
+			if !ok(p) {
+				continue
+			}
+			gg.AddNode(p)
+			if q := p.East(); ok(q) {
+				gg.AddEdge(p, q)
+			}
+			if q := p.South(); ok(q) {
+				gg.AddEdge(p, q)
+			}
+		}
+	}
+	return gg
+}
+
+// WriteGridDOT writes the adjacency of g's passable cells in Graphviz
+// DOT format. See GridGraph.
+func WriteGridDOT(w io.Writer, g Grid, passable func(rune) bool) error {
+	return WriteDOT(w, GridGraph(g, passable))
+}
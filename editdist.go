@@ -0,0 +1,91 @@
+package aoc
+
+// EditOp is one step in transforming one string into another, as
+// returned by EditDistanceOps.
+type EditOp struct {
+	Kind byte // '=' keep, 'S' substitute, 'I' insert, 'D' delete
+	From rune // for '=', 'S', 'D'
+	To   rune // for '=', 'S', 'I'
+}
+
+func (op EditOp) String() string {
+	switch op.Kind {
+	case '=':
+		return string(op.From)
+	case 'S':
+		return "[" + string(op.From) + "→" + string(op.To) + "]"
+	case 'I':
+		return "[+" + string(op.To) + "]"
+	case 'D':
+		return "[-" + string(op.From) + "]"
+	}
+	return "[?]"
+}
+
+// EditDistance returns the Levenshtein distance between a and b: the
+// minimum number of rune insertions, deletions, and substitutions to
+// turn a into b.
+func EditDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
+
+// EditDistanceOps is like EditDistance but also returns a minimal
+// sequence of operations turning a into b.
+func EditDistanceOps(a, b string) (dist int, ops []EditOp) {
+	ar, br := []rune(a), []rune(b)
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+		}
+	}
+	i, j := len(ar), len(br)
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && ar[i-1] == br[j-1] && d[i][j] == d[i-1][j-1]:
+			ops = append(ops, EditOp{'=', ar[i-1], br[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+1:
+			ops = append(ops, EditOp{'S', ar[i-1], br[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			ops = append(ops, EditOp{Kind: 'D', From: ar[i-1]})
+			i--
+		default:
+			ops = append(ops, EditOp{Kind: 'I', To: br[j-1]})
+			j--
+		}
+	}
+	for l, r := 0, len(ops)-1; l < r; l, r = l+1, r-1 {
+		ops[l], ops[r] = ops[r], ops[l]
+	}
+	return d[len(ar)][len(br)], ops
+}
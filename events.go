@@ -0,0 +1,83 @@
+package aoc
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// EventQueue is a discrete event simulation queue of T events keyed by
+// time. Events at the same time come out in the order they were
+// scheduled.
+//
+// The zero value is an empty queue at time 0, ready to use.
+type EventQueue[T any] struct {
+	h   eventHeap[T]
+	seq int
+	now int
+}
+
+type event[T any] struct {
+	at  int
+	seq int // tie-breaker: FIFO within a time
+	ev  T
+}
+
+// Now returns the time of the most recent event returned by Next.
+func (q *EventQueue[T]) Now() int { return q.now }
+
+func (q *EventQueue[T]) Len() int { return len(q.h) }
+
+// Schedule adds ev to happen at time at, which must not be in the past.
+func (q *EventQueue[T]) Schedule(at int, ev T) {
+	if at < q.now {
+		panic(fmt.Sprintf("EventQueue: scheduling at %d before now %d", at, q.now))
+	}
+	heap.Push(&q.h, event[T]{at, q.seq, ev})
+	q.seq++
+}
+
+// After schedules ev to happen d time units from now.
+func (q *EventQueue[T]) After(d int, ev T) { q.Schedule(q.now+d, ev) }
+
+// Next removes and returns the earliest event, advancing Now to its
+// time. It returns ok false if the queue is empty.
+func (q *EventQueue[T]) Next() (at int, ev T, ok bool) {
+	if len(q.h) == 0 {
+		return q.now, ev, false
+	}
+	e := heap.Pop(&q.h).(event[T])
+	q.now = e.at
+	return e.at, e.ev, true
+}
+
+// Run calls fn with each event in time order until the queue is empty
+// or fn returns false. fn may schedule more events.
+func (q *EventQueue[T]) Run(fn func(at int, ev T) (keepGoing bool)) {
+	for {
+		at, ev, ok := q.Next()
+		if !ok || !fn(at, ev) {
+			return
+		}
+	}
+}
+
+// eventHeap implements heap.Interface.
+type eventHeap[T any] []event[T]
+
+func (h eventHeap[T]) Len() int { return len(h) }
+func (h eventHeap[T]) Less(i, j int) bool {
+	if h[i].at != h[j].at {
+		return h[i].at < h[j].at
+	}
+	return h[i].seq < h[j].seq
+}
+func (h eventHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *eventHeap[T]) Push(x any)   { *h = append(*h, x.(event[T])) }
+func (h *eventHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = event[T]{}
+	*h = old[:n-1]
+	return e
+}
@@ -0,0 +1,89 @@
+package aoc
+
+import "fmt"
+
+// EvalExpr evaluates the integer arithmetic expression s, which may
+// contain parentheses and the binary operators + - * /. Operators bind
+// by their precedences (higher binds tighter), and operators of equal
+// precedence are left-associative.
+//
+// Only operators present in precedences are allowed, so
+// EvalExpr(s, map[byte]int{'+': 1, '*': 1}) is the puzzle's
+// left-to-right math and map[byte]int{'+': 2, '*': 1} is its
+// "addition first" math.
+func EvalExpr(s string, precedences map[byte]int) int {
+	e := &exprEval{s: s, prec: precedences}
+	v := e.expr(0)
+	if e.skipSpace(); e.i < len(s) {
+		panic(fmt.Sprintf("EvalExpr: unexpected %q at offset %d of %q", s[e.i], e.i, s))
+	}
+	return v
+}
+
+type exprEval struct {
+	s    string
+	i    int
+	prec map[byte]int
+}
+
+func (e *exprEval) skipSpace() {
+	for e.i < len(e.s) && e.s[e.i] == ' ' {
+		e.i++
+	}
+}
+
+// expr parses and evaluates operators of at least precedence minPrec
+// (precedence climbing).
+func (e *exprEval) expr(minPrec int) int {
+	lhs := e.operand()
+	for {
+		e.skipSpace()
+		if e.i >= len(e.s) {
+			return lhs
+		}
+		op := e.s[e.i]
+		p, ok := e.prec[op]
+		if !ok || p < minPrec {
+			return lhs
+		}
+		e.i++
+		rhs := e.expr(p + 1)
+		switch op {
+		case '+':
+			lhs += rhs
+		case '-':
+			lhs -= rhs
+		case '*':
+			lhs *= rhs
+		case '/':
+			lhs /= rhs
+		default:
+			panic(fmt.Sprintf("EvalExpr: unsupported operator %q", op))
+		}
+	}
+}
+
+func (e *exprEval) operand() int {
+	e.skipSpace()
+	if e.i >= len(e.s) {
+		panic(fmt.Sprintf("EvalExpr: unexpected end of %q", e.s))
+	}
+	if e.s[e.i] == '(' {
+		e.i++
+		v := e.expr(0)
+		e.skipSpace()
+		if e.i >= len(e.s) || e.s[e.i] != ')' {
+			panic(fmt.Sprintf("EvalExpr: missing ')' at offset %d of %q", e.i, e.s))
+		}
+		e.i++
+		return v
+	}
+	start := e.i
+	for e.i < len(e.s) && e.s[e.i] >= '0' && e.s[e.i] <= '9' {
+		e.i++
+	}
+	if start == e.i {
+		panic(fmt.Sprintf("EvalExpr: unexpected %q at offset %d of %q", e.s[e.i], e.i, e.s))
+	}
+	return Int(e.s[start:e.i])
+}
@@ -0,0 +1,150 @@
+package aoc
+
+// FlowNet is a flow network over nodes of type N for computing
+// max flow with Dinic's algorithm.
+//
+// The zero value is an empty network ready to use.
+type FlowNet[N comparable] struct {
+	idx   map[N]int
+	nodes []N
+	adj   [][]int // node -> edge indexes into edges
+	edges []flowEdge
+
+	src   int // of last MaxFlow call
+	level []int
+	iter  []int
+}
+
+type flowEdge struct {
+	to, cap, flow int
+	orig          bool // user-added, as opposed to a residual back edge
+}
+
+func (f *FlowNet[N]) node(n N) int {
+	if i, ok := f.idx[n]; ok {
+		return i
+	}
+	if f.idx == nil {
+		f.idx = map[N]int{}
+	}
+	i := len(f.nodes)
+	f.idx[n] = i
+	f.nodes = append(f.nodes, n)
+	f.adj = append(f.adj, nil)
+	return i
+}
+
+// AddEdge adds a directed edge from a to b with the given capacity.
+// For an undirected edge, add it in both directions.
+func (f *FlowNet[N]) AddEdge(a, b N, capacity int) {
+	ai, bi := f.node(a), f.node(b)
+	f.adj[ai] = append(f.adj[ai], len(f.edges))
+	f.edges = append(f.edges, flowEdge{to: bi, cap: capacity, orig: true})
+	f.adj[bi] = append(f.adj[bi], len(f.edges))
+	f.edges = append(f.edges, flowEdge{to: ai})
+}
+
+// MaxFlow returns the maximum flow from s to t.
+//
+// It resets any flow from a previous call first.
+// It panics if s == t.
+func (f *FlowNet[N]) MaxFlow(s, t N) int {
+	if s == t {
+		panic("MaxFlow with s == t")
+	}
+	si, ti := f.node(s), f.node(t)
+	for i := range f.edges {
+		f.edges[i].flow = 0
+	}
+	f.src = si
+	total := 0
+	for {
+		f.bfs(si)
+		if f.level[ti] < 0 {
+			break
+		}
+		f.iter = make([]int, len(f.nodes))
+		for {
+			pushed := f.dfs(si, ti, int(^uint(0)>>1))
+			if pushed == 0 {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+// bfs builds the level graph from s over edges with residual
+// capacity. Unreachable nodes have level -1.
+func (f *FlowNet[N]) bfs(s int) {
+	f.level = make([]int, len(f.nodes))
+	for i := range f.level {
+		f.level[i] = -1
+	}
+	f.level[s] = 0
+	q := []int{s}
+	for len(q) > 0 {
+		u := q[0]
+		q = q[1:]
+		for _, ei := range f.adj[u] {
+			e := &f.edges[ei]
+			if e.cap-e.flow > 0 && f.level[e.to] < 0 {
+				f.level[e.to] = f.level[u] + 1
+				q = append(q, e.to)
+			}
+		}
+	}
+}
+
+func (f *FlowNet[N]) dfs(u, t, limit int) int {
+	if u == t {
+		return limit
+	}
+	for ; f.iter[u] < len(f.adj[u]); f.iter[u]++ {
+		ei := f.adj[u][f.iter[u]]
+		e := &f.edges[ei]
+		if e.cap-e.flow <= 0 || f.level[e.to] != f.level[u]+1 {
+			continue
+		}
+		if d := f.dfs(e.to, t, min(limit, e.cap-e.flow)); d > 0 {
+			e.flow += d
+			f.edges[ei^1].flow -= d
+			return d
+		}
+	}
+	return 0
+}
+
+// MinCutSource returns the nodes on the source side of the minimum
+// cut found by the most recent MaxFlow call.
+func (f *FlowNet[N]) MinCutSource() Set[N] {
+	f.bfs(f.src)
+	s := Set[N]{}
+	for i, l := range f.level {
+		if l >= 0 {
+			s.Add(f.nodes[i])
+		}
+	}
+	return s
+}
+
+// MinCutEdges returns the saturated edges crossing the minimum cut
+// found by the most recent MaxFlow call. Their capacities sum to the
+// max flow.
+func (f *FlowNet[N]) MinCutEdges() [][2]N {
+	f.bfs(f.src)
+	var ret [][2]N
+	for u, eis := range f.adj {
+		if f.level[u] < 0 {
+			continue
+		}
+		for _, ei := range eis {
+			e := f.edges[ei]
+			if e.orig && f.level[e.to] < 0 {
+				ret = append(ret, [2]N{f.nodes[u], f.nodes[e.to]})
+			}
+		}
+	}
+	return ret
+}
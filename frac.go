@@ -0,0 +1,177 @@
+package aoc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Frac is an exact rational number with an int64 numerator and
+// denominator, always in lowest terms with a positive denominator.
+// Arithmetic panics on overflow rather than silently wrapping; switch
+// to big.Rat if that happens.
+//
+// The zero value is 0.
+type Frac struct {
+	num int64
+	dm1 int64 // denominator minus one, so the zero value is 0/1
+}
+
+// NewFrac returns num/den in lowest terms. It panics if den is zero.
+func NewFrac(num, den int64) Frac {
+	if den == 0 {
+		panic("NewFrac with zero denominator")
+	}
+	if den < 0 {
+		num, den = negChecked(num), negChecked(den)
+	}
+	g := gcd(num, den)
+	if g > 1 {
+		num /= g
+		den /= g
+	}
+	return Frac{num: num, dm1: den - 1}
+}
+
+// FracInt returns n as a Frac.
+func FracInt[T constraints.Integer](n T) Frac { return Frac{num: int64(n)} }
+
+func (f Frac) Num() int64 { return f.num }
+func (f Frac) Den() int64 { return f.dm1 + 1 }
+
+func (f Frac) IsInt() bool { return f.dm1 == 0 }
+func (f Frac) Sign() int   { return int(Sign(f.num)) }
+
+// Int returns f as an int if it's an integer.
+func (f Frac) Int() (v int, ok bool) { return int(f.num), f.IsInt() }
+
+func (f Frac) Float64() float64 { return float64(f.num) / float64(f.Den()) }
+
+func (f Frac) String() string {
+	if f.IsInt() {
+		return strconv.FormatInt(f.num, 10)
+	}
+	return fmt.Sprintf("%d/%d", f.num, f.Den())
+}
+
+// Rat returns f as a new big.Rat.
+func (f Frac) Rat() *big.Rat { return big.NewRat(f.num, f.Den()) }
+
+func (f Frac) Neg() Frac { return Frac{num: negChecked(f.num), dm1: f.dm1} }
+
+// Inv returns 1/f. It panics if f is zero.
+func (f Frac) Inv() Frac { return NewFrac(f.Den(), f.num) }
+
+func (f Frac) Add(g Frac) Frac {
+	// Scale by the lcm of the denominators rather than their product
+	// to keep intermediate values small.
+	d := gcd(f.Den(), g.Den())
+	fs, gs := g.Den()/d, f.Den()/d
+	return NewFrac(
+		addChecked(mulChecked(f.num, fs), mulChecked(g.num, gs)),
+		mulChecked(f.Den(), fs))
+}
+
+func (f Frac) Sub(g Frac) Frac { return f.Add(g.Neg()) }
+
+func (f Frac) Mul(g Frac) Frac {
+	// Cross-cancel first to keep intermediate values small.
+	g1, g2 := max(gcd(f.num, g.Den()), 1), max(gcd(g.num, f.Den()), 1)
+	return NewFrac(
+		mulChecked(f.num/g1, g.num/g2),
+		mulChecked(f.Den()/g2, g.Den()/g1))
+}
+
+// Div returns f/g. It panics if g is zero.
+func (f Frac) Div(g Frac) Frac { return f.Mul(g.Inv()) }
+
+// Cmp returns -1, 0, or 1 depending on whether f is less than, equal
+// to, or greater than g.
+func (f Frac) Cmp(g Frac) int {
+	if f == g {
+		return 0
+	}
+	if f.Sign() != g.Sign() {
+		return cmpInt(f.Sign(), g.Sign())
+	}
+	a, okA := mulOK(f.num, g.Den())
+	b, okB := mulOK(g.num, f.Den())
+	if okA && okB {
+		return cmpInt(a, b)
+	}
+	return f.Rat().Cmp(g.Rat())
+}
+
+func cmpInt[T constraints.Integer](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func mulOK(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return c, true
+}
+
+func mulChecked(a, b int64) int64 {
+	c, ok := mulOK(a, b)
+	if !ok {
+		panic(fmt.Sprintf("Frac overflow computing %d*%d", a, b))
+	}
+	return c
+}
+
+func addChecked(a, b int64) int64 {
+	c := a + b
+	if (c > a) != (b > 0) {
+		panic(fmt.Sprintf("Frac overflow computing %d+%d", a, b))
+	}
+	return c
+}
+
+func negChecked(a int64) int64 {
+	if a == math.MinInt64 {
+		panic("Frac overflow negating MinInt64")
+	}
+	return -a
+}
+
+// Fracs returns vs converted to Fracs.
+func Fracs[T constraints.Integer](vs []T) []Frac {
+	fs := make([]Frac, len(vs))
+	for i, v := range vs {
+		fs[i] = FracInt(v)
+	}
+	return fs
+}
+
+// SumFracs returns the sum of fs.
+func SumFracs(fs []Frac) Frac {
+	var sum Frac
+	for _, f := range fs {
+		sum = sum.Add(f)
+	}
+	return sum
+}
+
+// ProductFracs returns the product of fs, or 1 if fs is empty.
+func ProductFracs(fs []Frac) Frac {
+	p := FracInt(1)
+	for _, f := range fs {
+		p = p.Mul(f)
+	}
+	return p
+}
@@ -0,0 +1,156 @@
+package aoc
+
+import (
+	"fmt"
+	"math"
+)
+
+// Scalar is a number type with the arithmetic needed by Vec3, Line3
+// and Plane. Frac is exact; Float is fast.
+type Scalar[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Mul(T) T
+	Div(T) T
+	Sign() int
+}
+
+// Float is a float64 implementing Scalar.
+type Float float64
+
+// floatEpsilon is how close to zero a Float must be for Sign to
+// treat it as zero.
+const floatEpsilon = 1e-9
+
+func (f Float) Add(g Float) Float { return f + g }
+func (f Float) Sub(g Float) Float { return f - g }
+func (f Float) Mul(g Float) Float { return f * g }
+func (f Float) Div(g Float) Float { return f / g }
+
+// Sign returns -1, 0, or 1, treating values within 1e-9 of zero as
+// zero.
+func (f Float) Sign() int {
+	switch {
+	case math.Abs(float64(f)) < floatEpsilon:
+		return 0
+	case f < 0:
+		return -1
+	}
+	return 1
+}
+
+// Vec3 is a 3D point or vector of Scalar coordinates.
+type Vec3[T Scalar[T]] struct {
+	X, Y, Z T
+}
+
+// FracVec3 returns the exact vector (x, y, z).
+func FracVec3(x, y, z int64) Vec3[Frac] {
+	return Vec3[Frac]{FracInt(x), FracInt(y), FracInt(z)}
+}
+
+// FloatVec3 returns the vector (x, y, z).
+func FloatVec3(x, y, z float64) Vec3[Float] {
+	return Vec3[Float]{Float(x), Float(y), Float(z)}
+}
+
+func (v Vec3[T]) String() string { return fmt.Sprintf("(%v, %v, %v)", v.X, v.Y, v.Z) }
+
+func (v Vec3[T]) Add(w Vec3[T]) Vec3[T] { return Vec3[T]{v.X.Add(w.X), v.Y.Add(w.Y), v.Z.Add(w.Z)} }
+func (v Vec3[T]) Sub(w Vec3[T]) Vec3[T] { return Vec3[T]{v.X.Sub(w.X), v.Y.Sub(w.Y), v.Z.Sub(w.Z)} }
+func (v Vec3[T]) Scale(k T) Vec3[T]     { return Vec3[T]{v.X.Mul(k), v.Y.Mul(k), v.Z.Mul(k)} }
+
+func (v Vec3[T]) Dot(w Vec3[T]) T {
+	return v.X.Mul(w.X).Add(v.Y.Mul(w.Y)).Add(v.Z.Mul(w.Z))
+}
+
+func (v Vec3[T]) Cross(w Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		v.Y.Mul(w.Z).Sub(v.Z.Mul(w.Y)),
+		v.Z.Mul(w.X).Sub(v.X.Mul(w.Z)),
+		v.X.Mul(w.Y).Sub(v.Y.Mul(w.X)),
+	}
+}
+
+func (v Vec3[T]) IsZero() bool { return v.X.Sign() == 0 && v.Y.Sign() == 0 && v.Z.Sign() == 0 }
+
+// Equal reports whether v and w are the same point, within Float's
+// tolerance if T is Float.
+func (v Vec3[T]) Equal(w Vec3[T]) bool { return v.Sub(w).IsZero() }
+
+// Line3 is the line of points P + t·D. It's also a trajectory with
+// position P at time 0 and velocity D.
+type Line3[T Scalar[T]] struct {
+	P, D Vec3[T]
+}
+
+// At returns the point on l at parameter t.
+func (l Line3[T]) At(t T) Vec3[T] { return l.P.Add(l.D.Scale(t)) }
+
+// Parallel reports whether l and m have parallel directions. Lines
+// that are the same are parallel.
+func (l Line3[T]) Parallel(m Line3[T]) bool { return l.D.Cross(m.D).IsZero() }
+
+// ClosestApproach returns the parameters t on l and u on m of the
+// points where the lines come nearest each other. It returns ok
+// false if the lines are parallel, when every point is equally near.
+func (l Line3[T]) ClosestApproach(m Line3[T]) (t, u T, ok bool) {
+	w := l.P.Sub(m.P)
+	a, b, c := l.D.Dot(l.D), l.D.Dot(m.D), m.D.Dot(m.D)
+	d, e := l.D.Dot(w), m.D.Dot(w)
+	den := a.Mul(c).Sub(b.Mul(b))
+	if den.Sign() == 0 {
+		return t, u, false
+	}
+	t = b.Mul(e).Sub(c.Mul(d)).Div(den)
+	u = a.Mul(e).Sub(b.Mul(d)).Div(den)
+	return t, u, true
+}
+
+// Intersect returns the parameters t on l and u on m where the lines
+// cross, so l.At(t) == m.At(u). It returns ok false if they're
+// parallel or skew.
+//
+// For trajectories, the paths cross at those points but the objects
+// only collide if t == u.
+func (l Line3[T]) Intersect(m Line3[T]) (t, u T, ok bool) {
+	t, u, ok = l.ClosestApproach(m)
+	if !ok || !l.At(t).Equal(m.At(u)) {
+		var zero T
+		return zero, zero, false
+	}
+	return t, u, true
+}
+
+// Plane is the plane of points X with N·X = N·P: the plane through P
+// with normal N.
+type Plane[T Scalar[T]] struct {
+	P, N Vec3[T]
+}
+
+// PlaneThrough returns the plane through points a, b and c, which
+// must not be collinear.
+func PlaneThrough[T Scalar[T]](a, b, c Vec3[T]) Plane[T] {
+	n := b.Sub(a).Cross(c.Sub(a))
+	if n.IsZero() {
+		panic("PlaneThrough of collinear points")
+	}
+	return Plane[T]{P: a, N: n}
+}
+
+// Contains reports whether x is on p.
+func (p Plane[T]) Contains(x Vec3[T]) bool { return p.N.Dot(x.Sub(p.P)).Sign() == 0 }
+
+// Parallel reports whether l is parallel to p, including lying in
+// it.
+func (p Plane[T]) Parallel(l Line3[T]) bool { return p.N.Dot(l.D).Sign() == 0 }
+
+// Intersect returns the parameter t where l crosses p, at l.At(t).
+// It returns ok false if l is parallel to p.
+func (p Plane[T]) Intersect(l Line3[T]) (t T, ok bool) {
+	den := p.N.Dot(l.D)
+	if den.Sign() == 0 {
+		return t, false
+	}
+	return p.N.Dot(p.P.Sub(l.P)).Div(den), true
+}
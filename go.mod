@@ -1,5 +1,5 @@
 module github.com/bradfitz/aoc
 
-go 1.21.4
+go 1.23
 
 require golang.org/x/exp v0.0.0-20231127185646-65229373498e
@@ -0,0 +1,236 @@
+package aoc
+
+// Graph is a weighted graph over nodes of type N.
+//
+// The zero value is an empty undirected graph ready to use. Set
+// Directed before adding edges for a directed graph.
+//
+// Nodes and edges are kept in insertion order so iteration over them
+// is deterministic.
+type Graph[N comparable] struct {
+	Directed bool
+
+	nodes []N
+	out   map[N][]Edge[N]
+}
+
+// AddNode adds n if it's not already present.
+func (g *Graph[N]) AddNode(n N) {
+	if _, ok := g.out[n]; ok {
+		return
+	}
+	if g.out == nil {
+		g.out = map[N][]Edge[N]{}
+	}
+	g.out[n] = nil
+	g.nodes = append(g.nodes, n)
+}
+
+// AddEdge adds an edge from a to b with weight 1.
+func (g *Graph[N]) AddEdge(a, b N) { g.AddWeightedEdge(a, b, 1) }
+
+// AddWeightedEdge adds an edge from a to b with weight w, and from b
+// to a as well if g isn't Directed. If the edge already exists, its
+// weight is replaced.
+func (g *Graph[N]) AddWeightedEdge(a, b N, w int) {
+	g.AddNode(a)
+	g.AddNode(b)
+	g.setEdge(a, b, w)
+	if !g.Directed && a != b {
+		g.setEdge(b, a, w)
+	}
+}
+
+func (g *Graph[N]) setEdge(a, b N, w int) {
+	es := g.out[a]
+	for i := range es {
+		if es[i].To == b {
+			es[i].Weight = w
+			return
+		}
+	}
+	g.out[a] = append(es, Edge[N]{a, b, w})
+}
+
+// Len returns the number of nodes.
+func (g *Graph[N]) Len() int { return len(g.nodes) }
+
+// Has reports whether n is a node in g.
+func (g *Graph[N]) Has(n N) bool {
+	_, ok := g.out[n]
+	return ok
+}
+
+// Nodes returns g's nodes in insertion order.
+// The caller must not modify the returned slice.
+func (g *Graph[N]) Nodes() []N { return g.nodes }
+
+// Neighbors returns the nodes n has edges to.
+func (g *Graph[N]) Neighbors(n N) []N {
+	es := g.out[n]
+	ret := make([]N, len(es))
+	for i, e := range es {
+		ret[i] = e.To
+	}
+	return ret
+}
+
+// OutEdges returns the edges from n.
+// The caller must not modify the returned slice.
+func (g *Graph[N]) OutEdges(n N) []Edge[N] { return g.out[n] }
+
+// Weight returns the weight of the edge from a to b, if any.
+func (g *Graph[N]) Weight(a, b N) (w int, ok bool) {
+	for _, e := range g.out[a] {
+		if e.To == b {
+			return e.Weight, true
+		}
+	}
+	return 0, false
+}
+
+// Edges returns all of g's edges. For undirected graphs, each edge
+// is returned in both directions.
+func (g *Graph[N]) Edges() []Edge[N] {
+	var ret []Edge[N]
+	for _, n := range g.nodes {
+		ret = append(ret, g.out[n]...)
+	}
+	return ret
+}
+
+// Adjacency returns g as an adjacency map, as used by MinCut and
+// BronKerbosch.
+func (g *Graph[N]) Adjacency() map[N][]N {
+	m := make(map[N][]N, len(g.nodes))
+	for _, n := range g.nodes {
+		m[n] = g.Neighbors(n)
+	}
+	return m
+}
+
+// BFS returns the number of edges on the shortest path from start to
+// each node reachable from it, ignoring weights.
+func (g *Graph[N]) BFS(start N) map[N]int {
+	dist := map[N]int{start: 0}
+	var q Deque[N] // reuses its buffer, unlike q = q[1:]
+	q.PushBack(start)
+	for q.Len() > 0 {
+		n := q.PopFront()
+		for _, e := range g.out[n] {
+			if _, ok := dist[e.To]; !ok {
+				dist[e.To] = dist[n] + 1
+				q.PushBack(e.To)
+			}
+		}
+	}
+	return dist
+}
+
+// DFS calls visit for each node reachable from start, in depth-first
+// preorder, until visit returns false.
+func (g *Graph[N]) DFS(start N, visit func(N) (keepGoing bool)) {
+	seen := Set[N]{}
+	stack := []N{start}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[n] {
+			continue
+		}
+		seen.Add(n)
+		if !visit(n) {
+			return
+		}
+		es := g.out[n]
+		for i := len(es) - 1; i >= 0; i-- {
+			if !seen[es[i].To] {
+				stack = append(stack, es[i].To)
+			}
+		}
+	}
+}
+
+// Dijkstra returns the weighted shortest distance from start to each
+// node reachable from it, and each such node's predecessor on its
+// shortest path. Weights must be non-negative.
+func (g *Graph[N]) Dijkstra(start N) (dist map[N]int, prev map[N]N) {
+	dist = map[N]int{start: 0}
+	prev = map[N]N{}
+	// Every item stays in the items map until we return, so allocate
+	// them in bulk rather than one heap object per node.
+	q := PQ[N]{arena: new(Arena[PQItem[N]])}
+	items := map[N]*PQItem[N]{start: q.Push(start)}
+	for q.Len() > 0 {
+		n, d := q.PopMin()
+		for _, e := range g.out[n] {
+			nd := d + e.Weight
+			if it, ok := items[e.To]; ok {
+				// Popped items have a negative index and are done.
+				if it.index >= 0 && nd < it.Priority {
+					q.DecreaseKey(it, nd)
+					dist[e.To] = nd
+					prev[e.To] = n
+				}
+				continue
+			}
+			items[e.To] = q.PushWithPriority(e.To, nd)
+			dist[e.To] = nd
+			prev[e.To] = n
+		}
+	}
+	return dist, prev
+}
+
+// ShortestPath returns the weighted shortest path from a to b,
+// including both endpoints, and its length. It reports false if b
+// isn't reachable from a.
+func (g *Graph[N]) ShortestPath(a, b N) (path []N, length int, ok bool) {
+	dist, prev := g.Dijkstra(a)
+	length, ok = dist[b]
+	if !ok {
+		return nil, 0, false
+	}
+	for n := b; n != a; n = prev[n] {
+		path = append(path, n)
+	}
+	path = append(path, a)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, length, true
+}
+
+// Components returns g's connected components (weakly connected, for
+// directed graphs), each in discovery order.
+func (g *Graph[N]) Components() [][]N {
+	nbrs := g.out
+	if g.Directed {
+		nbrs = map[N][]Edge[N]{}
+		for _, n := range g.nodes {
+			for _, e := range g.out[n] {
+				nbrs[n] = append(nbrs[n], e)
+				nbrs[e.To] = append(nbrs[e.To], Edge[N]{e.To, n, e.Weight})
+			}
+		}
+	}
+	seen := Set[N]{}
+	var comps [][]N
+	for _, n := range g.nodes {
+		if seen[n] {
+			continue
+		}
+		seen.Add(n)
+		comp := []N{n}
+		for i := 0; i < len(comp); i++ {
+			for _, e := range nbrs[comp[i]] {
+				if !seen[e.To] {
+					seen.Add(e.To)
+					comp = append(comp, e.To)
+				}
+			}
+		}
+		comps = append(comps, comp)
+	}
+	return comps
+}
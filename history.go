@@ -0,0 +1,77 @@
+package aoc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// historyFile is where puzzle run times are appended, one JSON
+// object per line, in the current directory.
+const historyFile = "aoc-history.jsonl"
+
+type runRecord struct {
+	Func     string        `json:"func"`
+	Describe string        `json:"describe"` // git describe of the solutions repo
+	When     time.Time     `json:"when"`
+	Took     time.Duration `json:"took"`
+}
+
+func gitDescribe() string {
+	out, err := exec.Command("git", "describe", "--always", "--dirty").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// recordRuntime appends funcName's run time to the history file and
+// prints how it compares to the previous run, warning if it got
+// significantly slower.
+func recordRuntime(funcName string, took time.Duration) {
+	rec := runRecord{Func: funcName, Describe: gitDescribe(), When: time.Now(), Took: took}
+
+	var prev *runRecord
+	if f, err := os.ReadFile(historyFile); err == nil {
+		s := bufio.NewScanner(bytes.NewReader(f))
+		for s.Scan() {
+			var r runRecord
+			if json.Unmarshal(s.Bytes(), &r) == nil && r.Func == funcName {
+				prev = &r
+			}
+		}
+	}
+
+	switch {
+	case prev == nil:
+		fmt.Fprintf(os.Stderr, "%v took %v (first recorded run)\n", funcName, took.Round(time.Microsecond))
+	case prev.Took <= 0:
+		fmt.Fprintf(os.Stderr, "%v took %v (vs %v at %v)\n", funcName,
+			took.Round(time.Microsecond), prev.Took, prev.Describe)
+	default:
+		pct := 100 * (float64(took) - float64(prev.Took)) / float64(prev.Took)
+		fmt.Fprintf(os.Stderr, "%v took %v (%+.0f%% vs %v at %v)\n", funcName,
+			took.Round(time.Microsecond), pct, prev.Took.Round(time.Microsecond), prev.Describe)
+		// Ignore small absolute changes; they're mostly noise.
+		if took > prev.Took*3/2 && took-prev.Took > 50*time.Millisecond {
+			fmt.Fprintf(os.Stderr, "🐢 %v is significantly slower than its previous run\n", funcName)
+		}
+	}
+
+	j, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "recording run time: %v\n", err)
+		return
+	}
+	defer f.Close()
+	f.Write(append(j, '\n'))
+}
@@ -0,0 +1,44 @@
+package aoc
+
+// IDDFS finds a shortest path (in edges) from start to a node
+// satisfying isGoal by iterative deepening depth-first search,
+// trying depth limits 0, 1, 2, ... up to maxDepth.
+//
+// It uses memory proportional to the depth rather than the breadth
+// of the search, at the cost of revisiting shallow nodes. Only nodes
+// on the current path are tracked, to avoid cycles.
+//
+// It returns the path including start and the goal, or false if no
+// goal is within maxDepth.
+func IDDFS[N comparable](start N, neighbors func(N) []N, isGoal func(N) bool, maxDepth int) (path []N, ok bool) {
+	onPath := Set[N]{}
+	var dfs func(n N, limit int) bool
+	dfs = func(n N, limit int) bool {
+		path = append(path, n)
+		if isGoal(n) {
+			return true
+		}
+		if limit > 0 {
+			onPath.Add(n)
+			for _, next := range neighbors(n) {
+				if !onPath[next] && dfs(next, limit-1) {
+					return true
+				}
+			}
+			onPath.Delete(n)
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	for limit := 0; limit <= maxDepth; limit++ {
+		if dfs(start, limit) {
+			return path, true
+		}
+	}
+	return nil, false
+}
+
+// IDDFS is like the package-level IDDFS over g's edges.
+func (g *Graph[N]) IDDFS(start N, isGoal func(N) bool, maxDepth int) (path []N, ok bool) {
+	return IDDFS(start, g.Neighbors, isGoal, maxDepth)
+}
@@ -0,0 +1,193 @@
+package aoc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ParseIntcode parses a comma-separated Intcode program.
+func ParseIntcode(s string) []int {
+	var prog []int
+	for _, f := range strings.Split(strings.TrimSpace(s), ",") {
+		prog = append(prog, Int(strings.TrimSpace(f)))
+	}
+	return prog
+}
+
+// Intcode is an Intcode computer, from Advent of Code 2019.
+//
+// Input comes from In if non-nil, else from the Input queue. Output
+// goes to Out if non-nil, else is appended to Output.
+type Intcode struct {
+	Mem     []int
+	PC      int
+	RelBase int
+
+	In     func() int
+	Out    func(int)
+	Input  []int
+	Output []int
+
+	halted bool
+}
+
+// IntcodeStatus is why Intcode.Run returned.
+type IntcodeStatus int
+
+const (
+	IntcodeHalted    IntcodeStatus = iota // opcode 99 ran
+	IntcodeNeedInput                      // In is nil and Input is empty
+)
+
+// NewIntcode returns a computer with a copy of prog loaded.
+func NewIntcode(prog []int) *Intcode {
+	return &Intcode{Mem: slices.Clone(prog)}
+}
+
+// Clone returns a snapshot of c that runs independently from c. The
+// In and Out funcs, if any, are shared.
+func (c *Intcode) Clone() *Intcode {
+	c2 := *c
+	c2.Mem = slices.Clone(c.Mem)
+	c2.Input = slices.Clone(c.Input)
+	c2.Output = slices.Clone(c.Output)
+	return &c2
+}
+
+// Halted reports whether opcode 99 has run.
+func (c *Intcode) Halted() bool { return c.halted }
+
+// Send queues inputs for the program.
+func (c *Intcode) Send(v ...int) { c.Input = append(c.Input, v...) }
+
+// TakeOutput returns and clears any queued output.
+func (c *Intcode) TakeOutput() []int {
+	out := c.Output
+	c.Output = nil
+	return out
+}
+
+func (c *Intcode) grow(addr int) {
+	if addr < 0 {
+		panic(fmt.Sprintf("intcode: negative address %d at pc %d", addr, c.PC))
+	}
+	if addr >= len(c.Mem) {
+		c.Mem = append(c.Mem, make([]int, addr+1-len(c.Mem))...)
+	}
+}
+
+// addr returns the address of parameter n (1-based) of the current
+// instruction.
+func (c *Intcode) addr(n int) int {
+	mode := c.Mem[c.PC]
+	for i := 0; i < n+1; i++ {
+		mode /= 10
+	}
+	mode %= 10
+	p := c.PC + n
+	c.grow(p)
+	var a int
+	switch mode {
+	case 0:
+		a = c.Mem[p]
+	case 1:
+		a = p
+	case 2:
+		a = c.RelBase + c.Mem[p]
+	default:
+		panic(fmt.Sprintf("intcode: bad mode %d at pc %d", mode, c.PC))
+	}
+	c.grow(a)
+	return a
+}
+
+func (c *Intcode) get(n int) int { return c.Mem[c.addr(n)] }
+
+func (c *Intcode) set(n, v int) { c.Mem[c.addr(n)] = v }
+
+// Run runs until the program halts or needs input that isn't
+// available.
+func (c *Intcode) Run() IntcodeStatus {
+	for !c.halted {
+		c.grow(c.PC)
+		switch op := c.Mem[c.PC] % 100; op {
+		case 1:
+			c.set(3, c.get(1)+c.get(2))
+			c.PC += 4
+		case 2:
+			c.set(3, c.get(1)*c.get(2))
+			c.PC += 4
+		case 3:
+			var v int
+			if c.In != nil {
+				v = c.In()
+			} else if len(c.Input) > 0 {
+				v, c.Input = c.Input[0], c.Input[1:]
+			} else {
+				return IntcodeNeedInput
+			}
+			c.set(1, v)
+			c.PC += 2
+		case 4:
+			v := c.get(1)
+			if c.Out != nil {
+				c.Out(v)
+			} else {
+				c.Output = append(c.Output, v)
+			}
+			c.PC += 2
+		case 5:
+			if c.get(1) != 0 {
+				c.PC = c.get(2)
+			} else {
+				c.PC += 3
+			}
+		case 6:
+			if c.get(1) == 0 {
+				c.PC = c.get(2)
+			} else {
+				c.PC += 3
+			}
+		case 7:
+			c.set(3, boolInt(c.get(1) < c.get(2)))
+			c.PC += 4
+		case 8:
+			c.set(3, boolInt(c.get(1) == c.get(2)))
+			c.PC += 4
+		case 9:
+			c.RelBase += c.get(1)
+			c.PC += 2
+		case 99:
+			c.halted = true
+		default:
+			panic(fmt.Sprintf("intcode: bad opcode %d at pc %d", op, c.PC))
+		}
+	}
+	return IntcodeHalted
+}
+
+// RunIO queues inputs, runs until the program halts or blocks on
+// input, and returns the output produced.
+func (c *Intcode) RunIO(inputs ...int) []int {
+	c.Send(inputs...)
+	c.Run()
+	return c.TakeOutput()
+}
+
+// RunChan runs the program reading input from in and writing output
+// to out, closing out when the program halts. It's meant to be run
+// in its own goroutine, chaining computers together with channels.
+func (c *Intcode) RunChan(in <-chan int, out chan<- int) {
+	c.In = func() int { return <-in }
+	c.Out = func(v int) { out <- v }
+	c.Run()
+	close(out)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
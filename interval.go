@@ -0,0 +1,176 @@
+package aoc
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Interval is the inclusive range of integers [Lo, Hi].
+type Interval struct {
+	Lo, Hi int
+}
+
+func (iv Interval) String() string { return fmt.Sprintf("[%d,%d]", iv.Lo, iv.Hi) }
+
+// Empty reports whether iv contains no integers.
+func (iv Interval) Empty() bool { return iv.Hi < iv.Lo }
+
+// Len returns the number of integers in iv.
+func (iv Interval) Len() int {
+	if iv.Empty() {
+		return 0
+	}
+	return iv.Hi - iv.Lo + 1
+}
+
+func (iv Interval) Contains(v int) bool { return v >= iv.Lo && v <= iv.Hi }
+
+func (iv Interval) Overlaps(o Interval) bool {
+	return !iv.Empty() && !o.Empty() && iv.Lo <= o.Hi && o.Lo <= iv.Hi
+}
+
+// Intersect returns the overlap of iv and o, which may be empty.
+func (iv Interval) Intersect(o Interval) Interval {
+	return Interval{max(iv.Lo, o.Lo), min(iv.Hi, o.Hi)}
+}
+
+// IntervalSet is a set of integers stored as a sorted list of
+// disjoint, non-adjacent intervals.
+//
+// The zero value is an empty set ready to use.
+type IntervalSet struct {
+	ivs []Interval // sorted by Lo; disjoint and non-adjacent
+}
+
+// Intervals returns the set's sorted, merged intervals.
+// The caller must not modify the returned slice.
+func (s *IntervalSet) Intervals() []Interval { return s.ivs }
+
+// Add adds all the integers in iv to s.
+func (s *IntervalSet) Add(iv Interval) {
+	if iv.Empty() {
+		return
+	}
+	// First interval that could touch iv (ending at or after iv.Lo-1).
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Hi >= iv.Lo-1 })
+	j := i
+	for j < len(s.ivs) && s.ivs[j].Lo <= iv.Hi+1 {
+		iv.Lo = min(iv.Lo, s.ivs[j].Lo)
+		iv.Hi = max(iv.Hi, s.ivs[j].Hi)
+		j++
+	}
+	s.ivs = append(s.ivs[:i], append([]Interval{iv}, s.ivs[j:]...)...)
+}
+
+// Remove removes all the integers in iv from s.
+func (s *IntervalSet) Remove(iv Interval) {
+	if iv.Empty() {
+		return
+	}
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Hi >= iv.Lo })
+	j := i
+	var keep []Interval
+	for j < len(s.ivs) && s.ivs[j].Lo <= iv.Hi {
+		cur := s.ivs[j]
+		if cur.Lo < iv.Lo {
+			keep = append(keep, Interval{cur.Lo, iv.Lo - 1})
+		}
+		if cur.Hi > iv.Hi {
+			keep = append(keep, Interval{iv.Hi + 1, cur.Hi})
+		}
+		j++
+	}
+	s.ivs = append(s.ivs[:i], append(keep, s.ivs[j:]...)...)
+}
+
+// Has reports whether v is in s.
+func (s *IntervalSet) Has(v int) bool {
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Hi >= v })
+	return i < len(s.ivs) && s.ivs[i].Lo <= v
+}
+
+// TotalLen returns the number of integers in s.
+func (s *IntervalSet) TotalLen() int {
+	n := 0
+	for _, iv := range s.ivs {
+		n += iv.Len()
+	}
+	return n
+}
+
+// Gaps returns the sorted intervals within bounds that are not in s.
+func (s *IntervalSet) Gaps(bounds Interval) []Interval {
+	var gaps []Interval
+	next := bounds.Lo
+	for _, iv := range s.ivs {
+		if iv.Hi < next {
+			continue
+		}
+		if iv.Lo > bounds.Hi {
+			break
+		}
+		if iv.Lo > next {
+			gaps = append(gaps, Interval{next, iv.Lo - 1})
+		}
+		next = iv.Hi + 1
+	}
+	if next <= bounds.Hi {
+		gaps = append(gaps, Interval{next, bounds.Hi})
+	}
+	return gaps
+}
+
+// sweepEvents returns the coverage change points of ivs, sorted by
+// position, with changes at equal positions combined.
+func sweepEvents(ivs []Interval) (pos, delta []int) {
+	m := map[int]int{}
+	for _, iv := range ivs {
+		if iv.Empty() {
+			continue
+		}
+		m[iv.Lo]++
+		m[iv.Hi+1]--
+	}
+	for p := range m {
+		pos = append(pos, p)
+	}
+	slices.Sort(pos)
+	delta = make([]int, len(pos))
+	for i, p := range pos {
+		delta[i] = m[p]
+	}
+	return pos, delta
+}
+
+// CountCovered returns how many integers are in at least one of ivs.
+func CountCovered(ivs []Interval) int { return CountCoveredAtLeast(ivs, 1) }
+
+// CountCoveredAtLeast returns how many integers are in at least k of
+// ivs.
+func CountCoveredAtLeast(ivs []Interval, k int) int {
+	pos, delta := sweepEvents(ivs)
+	n, depth := 0, 0
+	for i, p := range pos {
+		depth += delta[i]
+		if depth >= k && i+1 < len(pos) {
+			n += pos[i+1] - p
+		}
+	}
+	return n
+}
+
+// MaxOverlapPoint returns the lowest integer contained in the most
+// intervals of ivs, and how many intervals contain it. It returns
+// count 0 if ivs is empty.
+func MaxOverlapPoint(ivs []Interval) (point, count int) {
+	pos, delta := sweepEvents(ivs)
+	depth := 0
+	for i, p := range pos {
+		depth += delta[i]
+		if depth > count {
+			point, count = p, depth
+		}
+	}
+	return point, count
+}
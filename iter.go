@@ -0,0 +1,160 @@
+package aoc
+
+import (
+	"iter"
+	"log"
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// All returns an iterator over g's points and their values, in
+// unspecified order, or row-major order if Deterministic is set.
+func (g Grid) All() iter.Seq2[Pt, rune] {
+	return func(yield func(Pt, rune) bool) {
+		if Deterministic {
+			for p := range keysOf(g) {
+				if !yield(p, g[p]) {
+					return
+				}
+			}
+			return
+		}
+		for p, r := range g {
+			if !yield(p, r) {
+				return
+			}
+		}
+	}
+}
+
+// LinesSeq returns an iterator over the lines of input.
+func LinesSeq() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, line := range LinesSeqY() {
+			if !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// LinesSeqY returns an iterator over the lines of input and their
+// row numbers, starting with 0.
+func LinesSeqY() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		s := Scanner()
+		for y := 0; s.Scan(); y++ {
+			if !yield(y, s.Text()) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// All returns an iterator over s's elements, in unspecified order, or
+// a fixed order if Deterministic is set.
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if Deterministic {
+			for v := range keysOf(s) {
+				if !yield(v) {
+					return
+				}
+			}
+			return
+		}
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over m's keys and values in insertion
+// order.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = e.next {
+			if !yield(e.k, e.v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over d's elements from front to back.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(i, d.buf[(d.head+i)%len(d.buf)]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over b's set bits, in increasing order.
+func (b Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for w, word := range b {
+			for word != 0 {
+				i := bits.TrailingZeros64(word)
+				if !yield(w*64 + i) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Submasks returns an iterator over every submask of mask, from mask
+// itself down to zero. See EnumerateSubmasks.
+func Submasks[T constraints.Integer](mask T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		EnumerateSubmasks(mask, yield)
+	}
+}
+
+// Permutations returns an iterator over all orderings of s, in
+// lexicographic order of indexes into s. The yielded slice is reused
+// between iterations; clone it to keep it.
+func Permutations[T any](s []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(s)
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		perm := make([]T, n)
+		for {
+			for i, j := range idx {
+				perm[i] = s[j]
+			}
+			if !yield(perm) {
+				return
+			}
+			// Advance idx to its next lexicographic permutation.
+			i := n - 2
+			for i >= 0 && idx[i] >= idx[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := n - 1
+			for idx[j] <= idx[i] {
+				j--
+			}
+			idx[i], idx[j] = idx[j], idx[i]
+			for l, r := i+1, n-1; l < r; l, r = l+1, r-1 {
+				idx[l], idx[r] = idx[r], idx[l]
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package aoc
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// WalkJSON decodes data and calls visit for every value in the tree,
+// parents before children, with object keys in sorted order.
+//
+// path is the sequence of object keys (strings) and array indexes
+// (ints) leading to v; it's empty for the root. v is one of nil,
+// bool, float64, string, []any, or map[string]any. visit must not
+// retain path.
+func WalkJSON(data []byte, visit func(path []any, v any)) {
+	WalkJSONPrune(data, func(path []any, v any) bool {
+		visit(path, v)
+		return true
+	})
+}
+
+// WalkJSONPrune is like WalkJSON but skips the children of any array
+// or object for which visit returns false.
+func WalkJSONPrune(data []byte, visit func(path []any, v any) (descend bool)) {
+	var root any
+	MustDo(json.Unmarshal(data, &root))
+	var walk func(path []any, v any)
+	walk = func(path []any, v any) {
+		if !visit(path, v) {
+			return
+		}
+		switch v := v.(type) {
+		case []any:
+			for i, e := range v {
+				walk(append(path, i), e)
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				walk(append(path, k), v[k])
+			}
+		}
+	}
+	walk(nil, root)
+}
+
+// SumJSONNumbers returns the sum of all numbers in the JSON document
+// data, skipping any object (and everything inside it) for which skip
+// returns true. skip may be nil.
+func SumJSONNumbers(data []byte, skip func(obj map[string]any) bool) float64 {
+	var sum float64
+	WalkJSONPrune(data, func(_ []any, v any) bool {
+		switch v := v.(type) {
+		case float64:
+			sum += v
+		case map[string]any:
+			return skip == nil || !skip(v)
+		}
+		return true
+	})
+	return sum
+}
@@ -0,0 +1,57 @@
+package aoc
+
+// SubsetSum reports whether some subset of s sums to target.
+// The elements of s must be non-negative.
+func SubsetSum(s []int, target int) bool {
+	if target < 0 {
+		return false
+	}
+	can := make([]bool, target+1)
+	can[0] = true
+	for _, v := range s {
+		for t := target; t >= v; t-- {
+			can[t] = can[t] || can[t-v]
+		}
+	}
+	return can[target]
+}
+
+// CountWaysToSum returns the number of subsets of s (distinguishing
+// equal elements at different indexes) that sum to target, as in the
+// eggnog containers puzzle. The elements of s must be non-negative.
+func CountWaysToSum(s []int, target int) int {
+	if target < 0 {
+		return 0
+	}
+	ways := make([]int, target+1)
+	ways[0] = 1
+	for _, v := range s {
+		for t := target; t >= v; t-- {
+			ways[t] += ways[t-v]
+		}
+	}
+	return ways[target]
+}
+
+// MinPartitionDiff returns the minimum possible difference between
+// the sums of two groups that s is split into. The elements of s must
+// be non-negative.
+func MinPartitionDiff(s []int) int {
+	total := 0
+	for _, v := range s {
+		total += v
+	}
+	half := total / 2
+	can := make([]bool, half+1)
+	can[0] = true
+	for _, v := range s {
+		for t := half; t >= v; t-- {
+			can[t] = can[t] || can[t-v]
+		}
+	}
+	for t := half; ; t-- {
+		if can[t] {
+			return total - 2*t
+		}
+	}
+}
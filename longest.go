@@ -0,0 +1,145 @@
+package aoc
+
+// LongestPath returns the length of the longest path from start to
+// end in a directed acyclic graph, where neighbors returns a node's
+// successors and the weights of the edges to them. It reports false
+// if end isn't reachable from start.
+//
+// The graph must be acyclic; see LongestSimplePath for graphs with
+// cycles.
+func LongestPath[N comparable](start, end N, neighbors func(N) map[N]int) (length int, ok bool) {
+	memo := map[N]int{} // node -> longest to end, or -1 if unreachable
+	var visit func(n N) int
+	visit = func(n N) int {
+		if n == end {
+			return 0
+		}
+		if v, ok := memo[n]; ok {
+			return v
+		}
+		best := -1
+		for next, w := range neighbors(n) {
+			if d := visit(next); d >= 0 {
+				best = max(best, d+w)
+			}
+		}
+		memo[n] = best
+		return best
+	}
+	d := visit(start)
+	return d, d >= 0
+}
+
+// LongestSimplePath returns the length of the longest path from start
+// to end that visits no node twice, via exhaustive DFS. It reports
+// false if end isn't reachable from start.
+//
+// This is NP-hard in general. Contract the graph first (see
+// ContractCorridors) to keep it tractable. The graph may have at most
+// 64 nodes after contraction; more panics.
+func LongestSimplePath[N comparable](start, end N, neighbors func(N) map[N]int) (length int, ok bool) {
+	// Index nodes and adjacency up front so the DFS is all bitmasks
+	// and slices.
+	idx := map[N]int{}
+	var adj [][]Edge[int]
+	var index func(n N) int
+	index = func(n N) int {
+		if i, ok := idx[n]; ok {
+			return i
+		}
+		i := len(adj)
+		if i == 64 {
+			panic("LongestSimplePath: too many nodes")
+		}
+		idx[n] = i
+		adj = append(adj, nil)
+		for next, w := range neighbors(n) {
+			ni := index(next)
+			adj[i] = append(adj[i], Edge[int]{i, ni, w})
+		}
+		return i
+	}
+	si := index(start)
+	ei, ok := idx[end]
+	if !ok {
+		return 0, false
+	}
+
+	// Prune: the sum of each unvisited node's best outgoing edge
+	// bounds how much further we can go.
+	bestOut := make([]int, len(adj))
+	for i, es := range adj {
+		for _, e := range es {
+			bestOut[i] = max(bestOut[i], e.Weight)
+		}
+	}
+
+	best := -1
+	var dfs func(u int, seen uint64, dist, remain int)
+	dfs = func(u int, seen uint64, dist, remain int) {
+		if u == ei {
+			best = max(best, dist)
+			return
+		}
+		if dist+remain <= best {
+			return
+		}
+		seen |= 1 << u
+		remain -= bestOut[u]
+		for _, e := range adj[u] {
+			if seen&(1<<e.To) == 0 {
+				dfs(e.To, seen, dist+e.Weight, remain)
+			}
+		}
+	}
+	total := 0
+	for _, v := range bestOut {
+		total += v
+	}
+	dfs(si, 0, 0, total)
+	return best, best >= 0
+}
+
+// ContractCorridors simplifies an undirected graph by replacing each
+// chain of degree-2 nodes with a single weighted edge between its
+// endpoints. Nodes in keep (such as the start and end) are never
+// removed.
+//
+// It returns the contracted graph as weighted adjacency, which can
+// be passed to LongestSimplePath via a map lookup.
+func ContractCorridors[N comparable](adj map[N][]N, keep ...N) map[N]map[N]int {
+	kept := SetOf(keep...)
+	isJunction := func(n N) bool { return kept[n] || len(adj[n]) != 2 }
+	ret := map[N]map[N]int{}
+	for n := range adj {
+		if !isJunction(n) {
+			continue
+		}
+		ret[n] = map[N]int{}
+		for _, next := range adj[n] {
+			// Walk the corridor until the next junction.
+			prev, cur, dist := n, next, 1
+			dead := false
+			for !isJunction(cur) {
+				nexts := adj[cur]
+				nxt := nexts[0]
+				if nxt == prev {
+					nxt = nexts[1]
+				}
+				if nxt == prev { // two edges back to the same node
+					dead = true
+					break
+				}
+				prev, cur = cur, nxt
+				dist++
+			}
+			if dead || cur == n {
+				continue
+			}
+			if old, ok := ret[n][cur]; !ok || dist > old {
+				ret[n][cur] = dist
+			}
+		}
+	}
+	return ret
+}
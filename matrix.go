@@ -0,0 +1,258 @@
+package aoc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Matrix is a dense matrix of exact rationals, for solving linear
+// systems whose coefficients would overflow or lose precision as
+// floats.
+type Matrix struct {
+	rows, cols int
+	a          []big.Rat // row-major
+}
+
+// NewMatrix returns a rows×cols matrix of zeros.
+func NewMatrix(rows, cols int) *Matrix {
+	return &Matrix{rows: rows, cols: cols, a: make([]big.Rat, rows*cols)}
+}
+
+// MatrixOf returns a matrix of the integers in rows, which must all
+// be the same length.
+func MatrixOf[T constraints.Integer](rows [][]T) *Matrix {
+	m := NewMatrix(len(rows), 0)
+	if len(rows) > 0 {
+		m = NewMatrix(len(rows), len(rows[0]))
+	}
+	for r, row := range rows {
+		if len(row) != m.cols {
+			panic("MatrixOf: ragged rows")
+		}
+		for c, v := range row {
+			m.SetInt(r, c, int64(v))
+		}
+	}
+	return m
+}
+
+func (m *Matrix) Rows() int { return m.rows }
+func (m *Matrix) Cols() int { return m.cols }
+
+// At returns the element at row r, column c. The caller may modify
+// it in place.
+func (m *Matrix) At(r, c int) *big.Rat {
+	if r < 0 || r >= m.rows || c < 0 || c >= m.cols {
+		panic(fmt.Sprintf("Matrix index (%d,%d) out of range %dx%d", r, c, m.rows, m.cols))
+	}
+	return &m.a[r*m.cols+c]
+}
+
+func (m *Matrix) Set(r, c int, v *big.Rat) { m.At(r, c).Set(v) }
+func (m *Matrix) SetInt(r, c int, v int64) { m.At(r, c).SetInt64(v) }
+
+func (m *Matrix) Clone() *Matrix {
+	m2 := NewMatrix(m.rows, m.cols)
+	for i := range m.a {
+		m2.a[i].Set(&m.a[i])
+	}
+	return m2
+}
+
+func (m *Matrix) String() string {
+	var sb strings.Builder
+	for r := range m.rows {
+		for c := range m.cols {
+			if c > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(m.At(r, c).RatString())
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// swapRows swaps rows i and j.
+func (m *Matrix) swapRows(i, j int) {
+	for c := range m.cols {
+		a, b := m.At(i, c), m.At(j, c)
+		var t big.Rat
+		t.Set(a)
+		a.Set(b)
+		b.Set(&t)
+	}
+}
+
+// RREF reduces m in place to reduced row echelon form using
+// Gauss-Jordan elimination and returns its rank.
+func (m *Matrix) RREF() (rank int) {
+	var t big.Rat
+	for c := 0; c < m.cols && rank < m.rows; c++ {
+		p := -1
+		for r := rank; r < m.rows; r++ {
+			if m.At(r, c).Sign() != 0 {
+				p = r
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		m.swapRows(rank, p)
+		var inv big.Rat
+		inv.Inv(m.At(rank, c))
+		for c2 := c; c2 < m.cols; c2++ {
+			m.At(rank, c2).Mul(m.At(rank, c2), &inv)
+		}
+		for r := range m.rows {
+			if r == rank {
+				continue
+			}
+			var f big.Rat
+			f.Set(m.At(r, c))
+			if f.Sign() == 0 {
+				continue
+			}
+			for c2 := c; c2 < m.cols; c2++ {
+				t.Mul(&f, m.At(rank, c2))
+				m.At(r, c2).Sub(m.At(r, c2), &t)
+			}
+		}
+		rank++
+	}
+	return rank
+}
+
+// Det returns the determinant of the square matrix m.
+func (m *Matrix) Det() *big.Rat {
+	if m.rows != m.cols {
+		panic("Det of non-square matrix")
+	}
+	w := m.Clone()
+	det := big.NewRat(1, 1)
+	var t big.Rat
+	for c := range w.cols {
+		p := -1
+		for r := c; r < w.rows; r++ {
+			if w.At(r, c).Sign() != 0 {
+				p = r
+				break
+			}
+		}
+		if p < 0 {
+			return new(big.Rat)
+		}
+		if p != c {
+			w.swapRows(c, p)
+			det.Neg(det)
+		}
+		det.Mul(det, w.At(c, c))
+		for r := c + 1; r < w.rows; r++ {
+			var f big.Rat
+			f.Quo(w.At(r, c), w.At(c, c))
+			if f.Sign() == 0 {
+				continue
+			}
+			for c2 := c; c2 < w.cols; c2++ {
+				t.Mul(&f, w.At(c, c2))
+				w.At(r, c2).Sub(w.At(r, c2), &t)
+			}
+		}
+	}
+	return det
+}
+
+// Solve returns the unique x with m·x = b. It returns ok false if
+// there's no solution or infinitely many. m is not modified.
+func (m *Matrix) Solve(b []*big.Rat) (x []*big.Rat, ok bool) {
+	if len(b) != m.rows {
+		panic("Solve: len(b) doesn't match rows")
+	}
+	aug := NewMatrix(m.rows, m.cols+1)
+	for r := range m.rows {
+		for c := range m.cols {
+			aug.Set(r, c, m.At(r, c))
+		}
+		aug.Set(r, m.cols, b[r])
+	}
+	// A unique solution needs a pivot in every column of m, which
+	// RREF puts on the diagonal. Then the augmented column can't also
+	// be a pivot (0 = nonzero), or the rank would exceed m.cols.
+	if aug.RREF() != m.cols {
+		return nil, false
+	}
+	for c := range m.cols {
+		if aug.At(c, c).Cmp(big.NewRat(1, 1)) != 0 {
+			return nil, false
+		}
+	}
+	x = make([]*big.Rat, m.cols)
+	for c := range m.cols {
+		x[c] = new(big.Rat).Set(aug.At(c, m.cols))
+	}
+	return x, true
+}
+
+// SolveInts is like Solve for an integer system a·x = b.
+func SolveInts[T constraints.Integer](a [][]T, b []T) (x []*big.Rat, ok bool) {
+	bs := make([]*big.Rat, len(b))
+	for i, v := range b {
+		bs[i] = new(big.Rat).SetInt64(int64(v))
+	}
+	return MatrixOf(a).Solve(bs)
+}
+
+// RatInt returns r as an int if it's an integer that fits.
+func RatInt(r *big.Rat) (v int, ok bool) {
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// SolveFloat returns the unique x with a·x = b for square a, using
+// Gaussian elimination with partial pivoting. It returns ok false if
+// a is singular (to within floating point error). a and b are not
+// modified.
+func SolveFloat(a [][]float64, b []float64) (x []float64, ok bool) {
+	n := len(a)
+	aug := make([][]float64, n)
+	for r := range a {
+		if len(a[r]) != n {
+			panic("SolveFloat: matrix not square")
+		}
+		aug[r] = append(append([]float64(nil), a[r]...), b[r])
+	}
+	for c := range n {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(aug[r][c]) > math.Abs(aug[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(aug[p][c]) < 1e-12 {
+			return nil, false
+		}
+		aug[c], aug[p] = aug[p], aug[c]
+		for r := c + 1; r < n; r++ {
+			f := aug[r][c] / aug[c][c]
+			for c2 := c; c2 <= n; c2++ {
+				aug[r][c2] -= f * aug[c][c2]
+			}
+		}
+	}
+	x = make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		s := aug[r][n]
+		for c := r + 1; c < n; c++ {
+			s -= aug[r][c] * x[c]
+		}
+		x[r] = s / aug[r][r]
+	}
+	return x, true
+}
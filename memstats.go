@@ -0,0 +1,66 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// runWithMemStats runs f and then prints to stderr how much it
+// allocated, how many GCs ran, and the peak live heap.
+func runWithMemStats(f func() any) any {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	// Sample the heap in the background for the peak. The
+	// runtime/metrics read doesn't stop the world, unlike
+	// ReadMemStats.
+	const heapMetric = "/memory/classes/heap/objects:bytes"
+	done := make(chan uint64)
+	stop := make(chan struct{})
+	go func() {
+		sample := []metrics.Sample{{Name: heapMetric}}
+		var peak uint64
+		t := time.NewTicker(10 * time.Millisecond)
+		defer t.Stop()
+		for {
+			metrics.Read(sample)
+			peak = max(peak, sample[0].Value.Uint64())
+			select {
+			case <-t.C:
+			case <-stop:
+				done <- peak
+				return
+			}
+		}
+	}()
+
+	v := f()
+
+	close(stop)
+	peak := <-done
+	runtime.ReadMemStats(&after)
+	peak = max(peak, after.HeapAlloc)
+	fmt.Fprintf(os.Stderr, "mem: %d allocs, %s allocated, %d GCs, peak heap %s\n",
+		after.Mallocs-before.Mallocs,
+		fmtBytes(after.TotalAlloc-before.TotalAlloc),
+		after.NumGC-before.NumGC,
+		fmtBytes(peak))
+	return v
+}
+
+func fmtBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,108 @@
+package aoc
+
+// MinCut returns a global minimum cut of the undirected, unweighted
+// graph adj using the Stoer–Wagner algorithm.
+//
+// Edges may be listed in adj in one or both directions. It returns
+// the nodes on one side of the cut and the edges crossing it. The
+// graph must be connected and have at least two nodes.
+func MinCut[N comparable](adj map[N][]N) (side Set[N], cut [][2]N) {
+	idx := map[N]int{}
+	var nodes []N
+	id := func(n N) int {
+		if i, ok := idx[n]; ok {
+			return i
+		}
+		idx[n] = len(nodes)
+		nodes = append(nodes, n)
+		return len(nodes) - 1
+	}
+	type edge struct{ a, b int }
+	edges := Set[edge]{}
+	for a := range keysOf(adj) {
+		ai := id(a)
+		for _, b := range adj[a] {
+			bi := id(b)
+			if ai == bi {
+				continue
+			}
+			edges.Add(edge{min(ai, bi), max(ai, bi)})
+		}
+	}
+	n := len(nodes)
+	if n < 2 {
+		panic("MinCut needs at least two nodes")
+	}
+
+	w := make([]map[int]int, n)
+	groups := make([][]int, n) // node -> original nodes merged into it
+	for i := range w {
+		w[i] = map[int]int{}
+		groups[i] = []int{i}
+	}
+	for e := range edges {
+		w[e.a][e.b]++
+		w[e.b][e.a]++
+	}
+
+	active := Set[int]{}
+	for i := range nodes {
+		active.Add(i)
+	}
+	best := -1
+	var bestGroup []int
+	var arena Arena[PQItem[int]] // PQ items, reused each phase
+	for len(active) > 1 {
+		// One phase: repeatedly add the most tightly connected node.
+		arena.Reset()
+		q := PQ[int]{arena: &arena}
+		items := map[int]*PQItem[int]{}
+		for v := range keysOf(active) {
+			items[v] = q.Push(v)
+		}
+		var s, t int = -1, -1
+		for q.Len() > 0 {
+			u, negKey := q.PopMin()
+			delete(items, u)
+			s, t = t, u
+			if q.Len() == 0 {
+				if best < 0 || -negKey < best {
+					best = -negKey
+					bestGroup = append([]int(nil), groups[t]...)
+				}
+			}
+			for v := range keysOf(w[u]) {
+				if it, ok := items[v]; ok {
+					q.DecreaseKey(it, it.Priority-w[u][v])
+				}
+			}
+		}
+
+		// Merge t into s.
+		for v, wt := range w[t] {
+			delete(w[v], t)
+			if v == s {
+				continue
+			}
+			w[s][v] += wt
+			w[v][s] += wt
+		}
+		w[t] = nil
+		groups[s] = append(groups[s], groups[t]...)
+		groups[t] = nil
+		active.Delete(t)
+	}
+
+	inSide := make([]bool, n)
+	side = Set[N]{}
+	for _, i := range bestGroup {
+		inSide[i] = true
+		side.Add(nodes[i])
+	}
+	for e := range keysOf(edges) {
+		if inSide[e.a] != inSide[e.b] {
+			cut = append(cut, [2]N{nodes[e.a], nodes[e.b]})
+		}
+	}
+	return side, cut
+}
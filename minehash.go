@@ -0,0 +1,52 @@
+package aoc
+
+import (
+	"crypto/md5"
+	"hash"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// MineHash returns the lowest non-negative n for which pred reports
+// true for the MD5 sum of prefix followed by n in decimal. The work
+// is spread across all CPUs, so pred must be safe for concurrent use.
+func MineHash(prefix string, pred func(sum []byte) bool) int {
+	return MineHashWith(md5.New, prefix, pred)
+}
+
+// MineHashWith is like MineHash but with a different hash function,
+// such as sha256.New.
+func MineHashWith(newHash func() hash.Hash, prefix string, pred func(sum []byte) bool) int {
+	type hasher struct {
+		h        hash.Hash
+		buf, sum []byte
+	}
+	pool := sync.Pool{New: func() any {
+		return &hasher{h: newHash(), buf: []byte(prefix)}
+	}}
+	n, _ := SearchRangeParallel(0, math.MaxInt, func(n int) bool {
+		hs := pool.Get().(*hasher)
+		defer pool.Put(hs)
+		hs.h.Reset()
+		hs.h.Write(strconv.AppendInt(hs.buf, int64(n), 10))
+		hs.sum = hs.h.Sum(hs.sum[:0])
+		return pred(hs.sum)
+	})
+	return n
+}
+
+// HasZeroHexPrefix reports whether sum, written in hex, starts with
+// at least n zeroes.
+func HasZeroHexPrefix(sum []byte, n int) bool {
+	for i := 0; i < n; i++ {
+		b := sum[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		if b&0xf != 0 {
+			return false
+		}
+	}
+	return true
+}
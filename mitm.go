@@ -0,0 +1,44 @@
+package aoc
+
+// MeetInTheMiddle searches the subsets of items by splitting items
+// into two halves, enumerating the 2^(n/2) subsets of each half, and
+// joining them through a map rather than enumerating all 2^n subsets.
+//
+// Each subset is folded into an aggregate A by applying add to zero
+// and each chosen item in turn. join is called for every pair of a
+// left-half and right-half aggregate where leftKey(left) equals
+// rightKey(right).
+//
+// For example, to count subsets summing to target, use integer sums
+// as the aggregate, with leftKey returning target-sum, rightKey
+// returning sum, and join incrementing a counter.
+func MeetInTheMiddle[T, A any, K comparable](items []T, zero A, add func(A, T) A, leftKey, rightKey func(A) K, join func(left, right A)) {
+	mid := len(items) / 2
+	right := map[K][]A{}
+	for _, a := range subsetFolds(items[mid:], zero, add) {
+		k := rightKey(a)
+		right[k] = append(right[k], a)
+	}
+	for _, l := range subsetFolds(items[:mid], zero, add) {
+		for _, r := range right[leftKey(l)] {
+			join(l, r)
+		}
+	}
+}
+
+// subsetFolds returns the fold of every subset of items, indexed by
+// the subset's bitmask.
+func subsetFolds[T, A any](items []T, zero A, add func(A, T) A) []A {
+	if len(items) > 30 {
+		panic("too many items for subset enumeration")
+	}
+	ret := make([]A, 1<<len(items))
+	ret[0] = zero
+	for i, it := range items {
+		bit := 1 << i
+		for m := 0; m < bit; m++ {
+			ret[bit|m] = add(ret[m], it)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,113 @@
+package aoc
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Triangular returns the n'th triangular number, 1+2+...+n.
+// That's the fuel cost of moving n steps when each step costs one
+// more than the last, and the peak height of a probe launched
+// upwards at velocity n.
+func Triangular[T constraints.Integer](n T) T {
+	// Halve the even factor first so only the result can overflow.
+	if n%2 == 0 {
+		return n / 2 * (n + 1)
+	}
+	return n * ((n + 1) / 2)
+}
+
+// SumRange returns a+(a+1)+...+b, or 0 if b < a.
+func SumRange[T constraints.Integer](a, b T) T {
+	if b < a {
+		return 0
+	}
+	// As in Triangular, halve whichever factor is even first.
+	s, n := a+b, b-a+1
+	if n%2 == 0 {
+		return s * (n / 2)
+	}
+	return s / 2 * n
+}
+
+// InvTriangular returns the largest n >= 0 with Triangular(n) <= x,
+// or -1 if x is negative. The smallest n with Triangular(n) >= x,
+// such as the slowest probe that reaches x, is InvTriangular(x-1)+1.
+func InvTriangular(x int) int {
+	if x < 0 {
+		return -1
+	}
+	// fits reports whether Triangular(n) <= x, dividing rather than
+	// multiplying so it can't overflow.
+	fits := func(n int) bool {
+		p, q := n/2, n+1
+		if n%2 != 0 {
+			p, q = n, (n+1)/2
+		}
+		return p <= x/q
+	}
+	n := int((math.Sqrt(8*float64(x)+1) - 1) / 2)
+	for !fits(n) {
+		n--
+	}
+	for fits(n + 1) {
+		n++
+	}
+	return n
+}
+
+// Digits returns the decimal digits of n, most significant first.
+// The sign of n is ignored and Digits(0) is [0].
+func Digits(n int) []int {
+	d := make([]int, NumDigits(n))
+	for i := len(d) - 1; i >= 0; i-- {
+		d[i] = Abs(n % 10)
+		n /= 10
+	}
+	return d
+}
+
+// NumDigits returns the number of decimal digits in n, ignoring its
+// sign. NumDigits(0) is 1.
+func NumDigits(n int) int {
+	c := 1
+	for n /= 10; n != 0; n /= 10 {
+		c++
+	}
+	return c
+}
+
+// pow10 returns 10**n.
+func pow10(n int) int {
+	p := 1
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
+// ConcatInts returns the number whose decimal digits are those of a
+// followed by those of b, as in ConcatInts(12, 345) == 12345.
+// b must be non-negative.
+func ConcatInts(a, b int) int {
+	if b < 0 {
+		panic("ConcatInts with negative b")
+	}
+	if a < 0 {
+		return a*pow10(NumDigits(b)) - b
+	}
+	return a*pow10(NumDigits(b)) + b
+}
+
+// SplitNumber splits n's decimal digits into two halves, as in
+// SplitNumber(1000) == 10, 0. It returns ok false if n has an odd
+// number of digits. n must be non-negative.
+func SplitNumber(n int) (hi, lo int, ok bool) {
+	nd := NumDigits(n)
+	if nd%2 != 0 {
+		return 0, 0, false
+	}
+	p := pow10(nd / 2)
+	return n / p, n % p, true
+}
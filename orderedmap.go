@@ -0,0 +1,98 @@
+package aoc
+
+// OrderedMap is a map that iterates in insertion order.
+//
+// Setting an existing key updates its value in place without changing
+// its position, which is the HASHMAP lens box semantics.
+//
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	m          map[K]*omEntry[K, V]
+	head, tail *omEntry[K, V]
+}
+
+type omEntry[K comparable, V any] struct {
+	k          K
+	v          V
+	prev, next *omEntry[K, V]
+}
+
+func (m *OrderedMap[K, V]) Len() int { return len(m.m) }
+
+func (m *OrderedMap[K, V]) Get(k K) (v V, ok bool) {
+	if e, ok := m.m[k]; ok {
+		return e.v, true
+	}
+	return v, false
+}
+
+func (m *OrderedMap[K, V]) Has(k K) bool {
+	_, ok := m.m[k]
+	return ok
+}
+
+// Set sets k to v. If k is new, it's added at the end.
+func (m *OrderedMap[K, V]) Set(k K, v V) {
+	if e, ok := m.m[k]; ok {
+		e.v = v
+		return
+	}
+	if m.m == nil {
+		m.m = map[K]*omEntry[K, V]{}
+	}
+	e := &omEntry[K, V]{k: k, v: v, prev: m.tail}
+	if m.tail != nil {
+		m.tail.next = e
+	} else {
+		m.head = e
+	}
+	m.tail = e
+	m.m[k] = e
+}
+
+// Delete removes k, if present.
+func (m *OrderedMap[K, V]) Delete(k K) {
+	e, ok := m.m[k]
+	if !ok {
+		return
+	}
+	delete(m.m, k)
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		m.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		m.tail = e.prev
+	}
+}
+
+// Range calls f for each key and value in insertion order until f
+// returns false.
+func (m *OrderedMap[K, V]) Range(f func(k K, v V) (keepGoing bool)) {
+	for e := m.head; e != nil; e = e.next {
+		if !f(e.k, e.v) {
+			return
+		}
+	}
+}
+
+// Keys returns the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	ret := make([]K, 0, len(m.m))
+	for e := m.head; e != nil; e = e.next {
+		ret = append(ret, e.k)
+	}
+	return ret
+}
+
+// Values returns the values in insertion order.
+func (m *OrderedMap[K, V]) Values() []V {
+	ret := make([]V, 0, len(m.m))
+	for e := m.head; e != nil; e = e.next {
+		ret = append(ret, e.v)
+	}
+	return ret
+}
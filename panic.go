@@ -0,0 +1,62 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// reportPanic prints a puzzle func's panic value e with the puzzle's
+// context and a stack trimmed to the puzzle's own frames.
+func reportPanic(e any, elapsed time.Duration) {
+	mode := "real"
+	if altInput != nil {
+		mode = "sample"
+	}
+	fmt.Fprintf(os.Stderr, "💥 %v panicked on %s input after %v: %v\n\n%s",
+		curPuzzle, mode, elapsed.Round(time.Millisecond), e, trimStack(debug.Stack()))
+}
+
+// trimStack returns the frames of stack, as from debug.Stack, that
+// are between the panic and the puzzle harness: dropping the
+// runtime's panic machinery, the harness itself, and the PC offsets.
+func trimStack(stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "goroutine ") {
+		lines = lines[1:]
+	}
+	var sb strings.Builder
+	for i := 0; i+1 < len(lines); i += 2 {
+		fn, loc := lines[i], lines[i+1]
+		if harnessFrame(fn) {
+			if sb.Len() > 0 {
+				break // reached the harness below the puzzle
+			}
+			continue // panic machinery above the puzzle
+		}
+		if j := strings.LastIndex(loc, " +0x"); j != -1 {
+			loc = loc[:j]
+		}
+		fmt.Fprintf(&sb, "%s\n%s\n", fn, loc)
+	}
+	return sb.String()
+}
+
+func harnessFrame(fn string) bool {
+	for _, prefix := range []string{
+		"runtime.",
+		"runtime/debug.",
+		"panic(",
+		"github.com/bradfitz/aoc.runPuzzle",
+		"github.com/bradfitz/aoc.runWithMemStats",
+		"github.com/bradfitz/aoc.reportPanic",
+		"github.com/bradfitz/aoc.Main",
+	} {
+		if strings.HasPrefix(fn, prefix) {
+			return true
+		}
+	}
+	return false
+}
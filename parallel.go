@@ -0,0 +1,100 @@
+package aoc
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParMap returns f applied to each of items, like MapSlice, but
+// spreads the calls across GOMAXPROCS goroutines. The results are in
+// the same order as items. f must be safe for concurrent use.
+func ParMap[T, R any](items []T, f func(T) R) []R {
+	ret := make([]R, len(items))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) {
+					return
+				}
+				ret[i] = f(items[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return ret
+}
+
+// SearchRangeParallel returns the lowest n in [lo, hi] for which
+// check returns true, spreading the search across GOMAXPROCS
+// goroutines. Work stops early once no lower answer is possible. It
+// returns false if check is false for the whole range.
+//
+// check must be safe for concurrent use.
+func SearchRangeParallel(lo, hi int, check func(n int) (found bool)) (n int, ok bool) {
+	if lo > hi {
+		return 0, false
+	}
+	const shard = 1024
+	var (
+		next atomic.Uint64 // next shard index to claim
+		wg   sync.WaitGroup
+		// last is the highest offset from lo. It's computed in
+		// uint64, where it can't overflow even for the whole int
+		// range, which has one more element than uint64 can count.
+		last    = uint64(hi) - uint64(lo)
+		nShards = last/shard + 1
+
+		mu    sync.Mutex
+		found bool
+		best  uint64 // lowest answer's offset from lo, if found
+	)
+	bestSoFar := func() (uint64, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		return best, found
+	}
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				si := next.Add(1) - 1
+				if si >= nShards {
+					return
+				}
+				start := si * shard
+				// Shards are claimed in order, so once a shard
+				// starts past a found answer, every lower shard is
+				// already claimed by a worker that will finish it.
+				if b, ok := bestSoFar(); ok && start > b {
+					return
+				}
+				end := min(start+(shard-1), last) // inclusive
+				for off := start; ; off++ {
+					if check(lo + int(off)) {
+						mu.Lock()
+						if !found || off < best {
+							best, found = off, true
+						}
+						mu.Unlock()
+						break
+					}
+					if off == end {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if !found {
+		return 0, false
+	}
+	return lo + int(best), true
+}
@@ -0,0 +1,178 @@
+package aoc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parser parses a value of type T from the start of s, returning the
+// value and the unconsumed remainder of s.
+type Parser[T any] func(s string) (v T, rest string, ok bool)
+
+// MustParse parses all of s with p. It panics if p fails or leaves
+// input unconsumed.
+func MustParse[T any](p Parser[T], s string) T {
+	v, rest, ok := p(s)
+	if !ok {
+		panic(fmt.Sprintf("parse failed at %q", trimForErr(s)))
+	}
+	if rest != "" {
+		panic(fmt.Sprintf("unparsed trailing input %q", trimForErr(rest)))
+	}
+	return v
+}
+
+func trimForErr(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
+
+// Literal matches lit exactly.
+func Literal(lit string) Parser[string] {
+	return func(s string) (string, string, bool) {
+		if rest, ok := strings.CutPrefix(s, lit); ok {
+			return lit, rest, true
+		}
+		return "", s, false
+	}
+}
+
+// Integer matches an optionally signed decimal integer.
+func Integer() Parser[int] {
+	return func(s string) (int, string, bool) {
+		i := 0
+		if i < len(s) && (s[i] == '-' || s[i] == '+') {
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return 0, s, false
+		}
+		v, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, s, false
+		}
+		return v, s[i:], true
+	}
+}
+
+// Word matches one or more letters.
+func Word() Parser[string] {
+	return func(s string) (string, string, bool) {
+		i := 0
+		for i < len(s) && (s[i] >= 'a' && s[i] <= 'z' || s[i] >= 'A' && s[i] <= 'Z') {
+			i++
+		}
+		if i == 0 {
+			return "", s, false
+		}
+		return s[:i], s[i:], true
+	}
+}
+
+// Map returns a parser that transforms p's result with f.
+func Map[T, R any](p Parser[T], f func(T) R) Parser[R] {
+	return func(s string) (R, string, bool) {
+		v, rest, ok := p(s)
+		if !ok {
+			var zero R
+			return zero, s, false
+		}
+		return f(v), rest, true
+	}
+}
+
+// Then matches a followed by b, returning b's result.
+func Then[A, B any](a Parser[A], b Parser[B]) Parser[B] {
+	return func(s string) (B, string, bool) {
+		var zero B
+		_, rest, ok := a(s)
+		if !ok {
+			return zero, s, false
+		}
+		v, rest, ok := b(rest)
+		if !ok {
+			return zero, s, false
+		}
+		return v, rest, true
+	}
+}
+
+// Before matches a followed by b, returning a's result.
+func Before[A, B any](a Parser[A], b Parser[B]) Parser[A] {
+	return func(s string) (A, string, bool) {
+		var zero A
+		v, rest, ok := a(s)
+		if !ok {
+			return zero, s, false
+		}
+		if _, rest, ok = b(rest); !ok {
+			return zero, s, false
+		}
+		return v, rest, true
+	}
+}
+
+// Between matches open, p, and close, returning p's result.
+func Between[O, T, C any](open Parser[O], p Parser[T], close Parser[C]) Parser[T] {
+	return Then(open, Before(p, close))
+}
+
+// Choice returns the result of the first of ps that matches.
+func Choice[T any](ps ...Parser[T]) Parser[T] {
+	return func(s string) (T, string, bool) {
+		for _, p := range ps {
+			if v, rest, ok := p(s); ok {
+				return v, rest, true
+			}
+		}
+		var zero T
+		return zero, s, false
+	}
+}
+
+// Many matches p zero or more times.
+func Many[T any](p Parser[T]) Parser[[]T] {
+	return func(s string) ([]T, string, bool) {
+		var vs []T
+		for {
+			v, rest, ok := p(s)
+			if !ok || len(rest) == len(s) {
+				return vs, s, true
+			}
+			vs = append(vs, v)
+			s = rest
+		}
+	}
+}
+
+// SepBy matches zero or more p separated by sep.
+func SepBy[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return func(s string) ([]T, string, bool) {
+		v, rest, ok := p(s)
+		if !ok {
+			return nil, s, true
+		}
+		more, rest, _ := Many(Then(sep, p))(rest)
+		return append([]T{v}, more...), rest, true
+	}
+}
+
+// Lazy returns a parser that calls f to get the real parser on first
+// use, for recursive grammars that would otherwise refer to
+// themselves before they're defined.
+func Lazy[T any](f func() Parser[T]) Parser[T] {
+	var p Parser[T]
+	return func(s string) (T, string, bool) {
+		if p == nil {
+			p = f()
+		}
+		return p(s)
+	}
+}
@@ -0,0 +1,73 @@
+package aoc
+
+// Vector is a point type that can be moved by a velocity, such as Pt
+// or Vox.
+type Vector[P any] interface {
+	Add(P) P
+	Mul(int) P
+}
+
+// Particle is a point moving with constant velocity, or constant
+// acceleration if Acc is non-zero.
+//
+// Each step, Acc is added to Vel and then Vel is added to Pos.
+type Particle[P Vector[P]] struct {
+	Pos, Vel, Acc P
+}
+
+// Step advances p one step.
+func (p *Particle[P]) Step() {
+	p.Vel = p.Vel.Add(p.Acc)
+	p.Pos = p.Pos.Add(p.Vel)
+}
+
+// StepN advances p n steps in constant time.
+func (p *Particle[P]) StepN(n int) {
+	p.Pos = p.PositionAt(n)
+	p.Vel = p.Vel.Add(p.Acc.Mul(n))
+}
+
+// PositionAt returns where p will be after t steps, without moving
+// it. Negative t works too, to run constant velocity backwards.
+func (p Particle[P]) PositionAt(t int) P {
+	return p.Pos.Add(p.Vel.Mul(t)).Add(p.Acc.Mul(Triangular(t)))
+}
+
+// PositionsAt returns the positions of ps after t steps.
+func PositionsAt[P Vector[P]](ps []Particle[P], t int) []P {
+	pos := make([]P, len(ps))
+	for i, p := range ps {
+		pos[i] = p.PositionAt(t)
+	}
+	return pos
+}
+
+// ClusterTime returns the first step t >= 0 at which the positions
+// of ps have the smallest bounding box (by width plus height). That's
+// when the moving points in the sky spell out a message; draw it
+// with GridOfPoints(PositionsAt(ps, t)).Draw().
+//
+// It assumes the particles converge and then diverge, as with
+// constant velocities.
+func ClusterTime(ps []Particle[Pt]) int {
+	spread := func(t int) int {
+		lo, hi := ps[0].PositionAt(t), ps[0].PositionAt(t)
+		for _, p := range ps[1:] {
+			q := p.PositionAt(t)
+			lo = Pt{min(lo.X, q.X), min(lo.Y, q.Y)}
+			hi = Pt{max(hi.X, q.X), max(hi.Y, q.Y)}
+		}
+		return hi.X - lo.X + hi.Y - lo.Y
+	}
+	if len(ps) == 0 {
+		return 0
+	}
+	t, cur := 0, spread(0)
+	for {
+		next := spread(t + 1)
+		if next >= cur {
+			return t
+		}
+		t, cur = t+1, next
+	}
+}
@@ -0,0 +1,52 @@
+package aoc
+
+// onSegment reports whether p lies on the segment from a to b.
+func onSegment(p, a, b Pt) bool {
+	cross := (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+	return cross == 0 &&
+		min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+		min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
+
+// OnPolygonBoundary reports whether p lies on an edge of the closed
+// polygon with the given vertices.
+func OnPolygonBoundary(p Pt, vertices []Pt) bool {
+	for i, a := range vertices {
+		if onSegment(p, a, vertices[(i+1)%len(vertices)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// InPolygon reports whether p is inside or on the boundary of the
+// closed polygon with the given vertices, in order. The polygon
+// needn't be convex but mustn't self-intersect.
+//
+// For "is this tile enclosed by the loop" questions where the loop's
+// own tiles don't count, also check OnPolygonBoundary.
+func InPolygon(p Pt, vertices []Pt) bool {
+	if OnPolygonBoundary(p, vertices) {
+		return true
+	}
+	// Cast a ray toward +X and count edge crossings. Each edge is
+	// treated as half-open in Y so vertices aren't counted twice.
+	inside := false
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		// X coordinate where the edge crosses the ray's line, compared
+		// without division: p.X < a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y).
+		lhs := (p.X - a.X) * (b.Y - a.Y)
+		rhs := (p.Y - a.Y) * (b.X - a.X)
+		if b.Y < a.Y {
+			lhs, rhs = -lhs, -rhs
+		}
+		if lhs < rhs {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,69 @@
+package aoc
+
+// Power returns x combined with itself n times under the associative
+// operation mul, using O(log n) multiplications by repeated squaring.
+// identity is the result for n == 0.
+//
+// This applies anything that composes, such as permutations (see
+// ComposePerm), matrices, or modular linear maps, huge numbers of
+// times.
+func Power[T any](x T, n int, mul func(a, b T) T, identity T) T {
+	if n < 0 {
+		panic("negative Power")
+	}
+	result := identity
+	for n > 0 {
+		if n&1 == 1 {
+			result = mul(result, x)
+		}
+		n >>= 1
+		if n > 0 {
+			x = mul(x, x)
+		}
+	}
+	return result
+}
+
+// FuncPowerCycle returns f applied n times to s, by stepping f until
+// a state repeats and then skipping whole cycles.
+//
+// It's fast when f cycles quickly, even for n like 10^12, but it
+// remembers every state until the first repeat, so its time and
+// memory grow with the cycle length, up to n. If f's effect can be
+// represented as something composable instead (a permutation, a
+// matrix, or a modular linear map like a card shuffle), use Power,
+// which takes O(log n) steps regardless.
+func FuncPowerCycle[S comparable](f func(S) S, n int, s S) S {
+	seenAt := map[S]int{s: 0}
+	for i := 1; i <= n; i++ {
+		s = f(s)
+		if j, ok := seenAt[s]; ok {
+			cycle := i - j
+			for range (n - i) % cycle {
+				s = f(s)
+			}
+			return s
+		}
+		seenAt[s] = i
+	}
+	return s
+}
+
+// ComposePerm returns the permutation that applies a and then b,
+// where a permutation p moves the element at index p[i] to index i.
+func ComposePerm(a, b []int) []int {
+	ret := make([]int, len(a))
+	for i := range ret {
+		ret[i] = a[b[i]]
+	}
+	return ret
+}
+
+// PermPower returns permutation p applied n times. See ComposePerm.
+func PermPower(p []int, n int) []int {
+	id := make([]int, len(p))
+	for i := range id {
+		id[i] = i
+	}
+	return Power(p, n, ComposePerm, id)
+}
@@ -0,0 +1,92 @@
+package aoc
+
+import "container/heap"
+
+// PQ is a min priority queue of T values.
+//
+// The zero value is an empty queue ready to use.
+type PQ[T any] struct {
+	h     pqHeap[T]
+	arena *Arena[PQItem[T]] // if non-nil, where items are allocated
+}
+
+// PQItem is a handle to a value in a PQ, as returned by
+// PQ.PushWithPriority, for use with PQ.DecreaseKey.
+type PQItem[T any] struct {
+	Value    T
+	Priority int
+	index    int // in pqHeap, or -1 once popped
+}
+
+func (q *PQ[T]) Len() int { return len(q.h) }
+
+// Push adds v with priority 0.
+func (q *PQ[T]) Push(v T) *PQItem[T] { return q.PushWithPriority(v, 0) }
+
+// PushWithPriority adds v to the queue with the given priority and
+// returns its handle.
+func (q *PQ[T]) PushWithPriority(v T, pri int) *PQItem[T] {
+	var it *PQItem[T]
+	if q.arena != nil {
+		it = q.arena.New()
+	} else {
+		it = new(PQItem[T])
+	}
+	it.Value, it.Priority = v, pri
+	heap.Push(&q.h, it)
+	return it
+}
+
+// PopMin removes and returns the value with the lowest priority.
+// It panics if the queue is empty.
+func (q *PQ[T]) PopMin() (v T, pri int) {
+	it := heap.Pop(&q.h).(*PQItem[T])
+	return it.Value, it.Priority
+}
+
+// PeekMin returns the value with the lowest priority without removing it.
+// It panics if the queue is empty.
+func (q *PQ[T]) PeekMin() (v T, pri int) {
+	it := q.h[0]
+	return it.Value, it.Priority
+}
+
+// DecreaseKey lowers the priority of it, which must still be in q.
+// If pri isn't lower than its current priority, DecreaseKey does nothing.
+func (q *PQ[T]) DecreaseKey(it *PQItem[T], pri int) {
+	if it.index < 0 {
+		panic("DecreaseKey of item no longer in queue")
+	}
+	if pri >= it.Priority {
+		return
+	}
+	it.Priority = pri
+	heap.Fix(&q.h, it.index)
+}
+
+// pqHeap implements heap.Interface.
+type pqHeap[T any] []*PQItem[T]
+
+func (h pqHeap[T]) Len() int           { return len(h) }
+func (h pqHeap[T]) Less(i, j int) bool { return h[i].Priority < h[j].Priority }
+func (h pqHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *pqHeap[T]) Push(x any) {
+	it := x.(*PQItem[T])
+	it.index = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *pqHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	it.index = -1
+	*h = old[:n-1]
+	return it
+}
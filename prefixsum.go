@@ -0,0 +1,64 @@
+package aoc
+
+// PrefixSum2D is a summed-area table for O(1) sums over rectangles
+// of a 2D grid of ints.
+type PrefixSum2D struct {
+	w, h int
+	s    [][]int // s[y+1][x+1] is the sum of all cells <= (x, y)
+}
+
+// NewPrefixSum2D returns a PrefixSum2D for rows, indexed as
+// rows[y][x]. Short rows are treated as zero-padded.
+func NewPrefixSum2D(rows [][]int) *PrefixSum2D {
+	w := 0
+	for _, r := range rows {
+		w = max(w, len(r))
+	}
+	p := &PrefixSum2D{w: w, h: len(rows), s: make([][]int, len(rows)+1)}
+	p.s[0] = make([]int, w+1)
+	for y, r := range rows {
+		p.s[y+1] = make([]int, w+1)
+		for x := 0; x < w; x++ {
+			v := 0
+			if x < len(r) {
+				v = r[x]
+			}
+			p.s[y+1][x+1] = v + p.s[y][x+1] + p.s[y+1][x] - p.s[y][x]
+		}
+	}
+	return p
+}
+
+// PrefixSumGrid returns a PrefixSum2D over g's bounding box, with
+// each cell's value given by val. The table's (0, 0) is g's
+// minimum corner.
+func PrefixSumGrid(g Grid, val func(rune) int) *PrefixSum2D {
+	minX, minY, maxX, maxY := g.Bounds()
+	rows := make([][]int, maxY-minY+1)
+	for y := range rows {
+		rows[y] = make([]int, maxX-minX+1)
+		for x := range rows[y] {
+			if r, ok := g[Pt{x + minX, y + minY}]; ok {
+				rows[y][x] = val(r)
+			}
+		}
+	}
+	return NewPrefixSum2D(rows)
+}
+
+// Width returns the table's width.
+func (p *PrefixSum2D) Width() int { return p.w }
+
+// Height returns the table's height.
+func (p *PrefixSum2D) Height() int { return p.h }
+
+// Sum returns the sum of the rectangle with inclusive corners a and
+// b. The rectangle is clipped to the table.
+func (p *PrefixSum2D) Sum(a, b Pt) int {
+	x0, x1 := max(min(a.X, b.X), 0), min(max(a.X, b.X), p.w-1)
+	y0, y1 := max(min(a.Y, b.Y), 0), min(max(a.Y, b.Y), p.h-1)
+	if x0 > x1 || y0 > y1 {
+		return 0
+	}
+	return p.s[y1+1][x1+1] - p.s[y0][x1+1] - p.s[y1+1][x0] + p.s[y0][x0]
+}
@@ -0,0 +1,181 @@
+package aoc
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Isqrt returns the largest integer r with r*r <= n.
+// It panics if n is negative.
+func Isqrt(n int) int {
+	if n < 0 {
+		panic("Isqrt of negative number")
+	}
+	r := int(math.Sqrt(float64(n)))
+	// Fix up float rounding for large n, dividing rather than
+	// squaring to avoid overflow.
+	for r > 0 && r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
+
+// Primes returns the primes <= n in increasing order, using a sieve
+// of Eratosthenes.
+func Primes(n int) []int {
+	if n < 2 {
+		return nil
+	}
+	composite := make([]bool, n+1)
+	var ps []int
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		ps = append(ps, i)
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+	return ps
+}
+
+// Divisors returns the positive divisors of n in increasing order,
+// including 1 and n. It returns nil if n < 1.
+func Divisors(n int) []int {
+	if n < 1 {
+		return nil
+	}
+	var lo, hi []int
+	for d := 1; d <= n/d; d++ {
+		if n%d != 0 {
+			continue
+		}
+		lo = append(lo, d)
+		if d != n/d {
+			hi = append(hi, n/d)
+		}
+	}
+	slices.Reverse(hi)
+	return append(lo, hi...)
+}
+
+// IsPrime reports whether n is prime. It's exact for all int values,
+// using deterministic Miller–Rabin.
+func IsPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range smallPrimes {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	u := uint64(n)
+	d, s := u-1, 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+	// These bases are enough for all n < 2**64.
+	for _, a := range smallPrimes {
+		x := powMod(uint64(a), d, u)
+		if x == 1 || x == u-1 {
+			continue
+		}
+		composite := true
+		for range s - 1 {
+			x = mulMod(x, x, u)
+			if x == u-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+var smallPrimes = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// Factorize returns the prime factorization of n as a map from prime
+// to exponent, so Factorize(360) is {2:3, 3:2, 5:1}. Factorize(1) is
+// empty. Large factors are found with Pollard's rho, so it's fast
+// for any int. It panics if n < 1.
+func Factorize(n int) map[int]int {
+	if n < 1 {
+		panic("Factorize of non-positive number")
+	}
+	f := map[int]int{}
+	for p := 2; p < 1000 && p <= n/p; p++ {
+		for n%p == 0 {
+			f[p]++
+			n /= p
+		}
+	}
+	var split func(n int)
+	split = func(n int) {
+		switch {
+		case n == 1:
+			return
+		case IsPrime(n):
+			f[n]++
+			return
+		}
+		d := pollardRho(n)
+		split(d)
+		split(n / d)
+	}
+	split(n)
+	return f
+}
+
+// pollardRho returns a non-trivial divisor of the composite n, which
+// has no small factors. It's deterministic: it tries increasing
+// polynomial constants rather than random ones.
+func pollardRho(n int) int {
+	u := uint64(n)
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod(x, x, u) + c) % u }
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			d = gcd(absDiffU64(x, y), u)
+		}
+		if d != u {
+			return int(d)
+		}
+	}
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, r := bits.Div64(hi%m, lo, m)
+	return r
+}
+
+func powMod(b, e, m uint64) uint64 {
+	r := uint64(1)
+	b %= m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = mulMod(r, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return r
+}
+
+func absDiffU64(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,72 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProgressBar reports progress of a long computation to stderr. Its
+// methods are safe for concurrent use.
+type ProgressBar struct {
+	total int64
+	n     atomic.Int64
+	start time.Time
+	w     io.Writer
+
+	mu   sync.Mutex
+	last time.Time // last print
+}
+
+// Progress returns a ProgressBar for total units of work.
+func Progress(total int) *ProgressBar {
+	return &ProgressBar{total: int64(total), start: time.Now(), w: os.Stderr}
+}
+
+// Incr records one unit of work done.
+func (p *ProgressBar) Incr() { p.Add(1) }
+
+// Add records n units of work done. It prints at most a few times a
+// second.
+func (p *ProgressBar) Add(n int) {
+	p.n.Add(int64(n))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if now := time.Now(); now.Sub(p.last) >= 200*time.Millisecond {
+		p.last = now
+		p.print(now)
+	}
+}
+
+// Done prints the final state and ends the progress line.
+func (p *ProgressBar) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.print(time.Now())
+	fmt.Fprintln(p.w)
+}
+
+func (p *ProgressBar) print(now time.Time) {
+	const width = 30
+	n := p.n.Load()
+	frac := 0.0
+	if p.total > 0 {
+		frac = min(float64(n)/float64(p.total), 1)
+	}
+	elapsed := now.Sub(p.start)
+	rate := float64(n) / elapsed.Seconds()
+	eta := "?"
+	if n > 0 && n < p.total {
+		eta = (time.Duration(float64(elapsed) * float64(p.total-n) / float64(n))).Round(time.Second).String()
+	} else if n >= p.total {
+		eta = "0s"
+	}
+	filled := int(frac * width)
+	fmt.Fprintf(p.w, "\r[%s%s] %5.1f%% %d/%d %.0f/s ETA %s ",
+		strings.Repeat("#", filled), strings.Repeat(".", width-filled),
+		100*frac, n, p.total, rate, eta)
+}
@@ -0,0 +1,117 @@
+package aoc
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// RangeMap is a piecewise map from ints to ints: each source interval
+// is shifted by a fixed delta, and values outside every source
+// interval map to themselves.
+//
+// The zero value is the identity map, ready to use.
+type RangeMap struct {
+	ents []rangeMapEnt // sorted by Src.Lo; disjoint
+}
+
+type rangeMapEnt struct {
+	src   Interval
+	delta int
+}
+
+// Add maps each v in src to v+delta.
+// It panics if src overlaps a previously added interval.
+func (m *RangeMap) Add(src Interval, delta int) {
+	if src.Empty() {
+		return
+	}
+	i := sort.Search(len(m.ents), func(i int) bool { return m.ents[i].src.Lo > src.Lo })
+	if (i > 0 && m.ents[i-1].src.Overlaps(src)) || (i < len(m.ents) && m.ents[i].src.Overlaps(src)) {
+		panic(fmt.Sprintf("RangeMap.Add: %v overlaps existing interval", src))
+	}
+	m.ents = slices.Insert(m.ents, i, rangeMapEnt{src, delta})
+}
+
+// AddMapping maps the n values starting at src to the n values
+// starting at dst. The argument order matches the "destination
+// source length" lines of the seed-to-location puzzle.
+func (m *RangeMap) AddMapping(dst, src, n int) {
+	m.Add(Interval{src, src + n - 1}, dst-src)
+}
+
+// Apply returns the value v maps to.
+func (m *RangeMap) Apply(v int) int {
+	i := sort.Search(len(m.ents), func(i int) bool { return m.ents[i].src.Hi >= v })
+	if i < len(m.ents) && m.ents[i].src.Contains(v) {
+		return v + m.ents[i].delta
+	}
+	return v
+}
+
+// pieces partitions iv into sub-intervals that each map with a single
+// delta, including identity (zero delta) gaps, in order.
+func (m *RangeMap) pieces(iv Interval) []rangeMapEnt {
+	var ret []rangeMapEnt
+	next := iv.Lo
+	for _, e := range m.ents {
+		if next > iv.Hi {
+			break
+		}
+		if e.src.Hi < next {
+			continue
+		}
+		if e.src.Lo > iv.Hi {
+			break
+		}
+		if e.src.Lo > next {
+			ret = append(ret, rangeMapEnt{Interval{next, e.src.Lo - 1}, 0})
+		}
+		ret = append(ret, rangeMapEnt{e.src.Intersect(iv), e.delta})
+		if e.src.Hi >= iv.Hi {
+			return ret
+		}
+		next = e.src.Hi + 1
+	}
+	if next <= iv.Hi {
+		ret = append(ret, rangeMapEnt{Interval{next, iv.Hi}, 0})
+	}
+	return ret
+}
+
+// ApplyInterval returns the intervals that iv maps to, splitting it
+// where it straddles source interval boundaries. The results are in
+// source order, not sorted.
+func (m *RangeMap) ApplyInterval(iv Interval) []Interval {
+	var ret []Interval
+	for _, p := range m.pieces(iv) {
+		ret = append(ret, Interval{p.src.Lo + p.delta, p.src.Hi + p.delta})
+	}
+	return ret
+}
+
+// ApplyIntervals is like ApplyInterval for each of ivs.
+func (m *RangeMap) ApplyIntervals(ivs []Interval) []Interval {
+	var ret []Interval
+	for _, iv := range ivs {
+		ret = append(ret, m.ApplyInterval(iv)...)
+	}
+	return ret
+}
+
+// Compose returns a RangeMap equivalent to applying m and then next.
+func (m *RangeMap) Compose(next *RangeMap) *RangeMap {
+	ret := new(RangeMap)
+	for _, p := range m.pieces(Interval{math.MinInt, math.MaxInt}) {
+		dst := Interval{p.src.Lo + p.delta, p.src.Hi + p.delta}
+		for _, q := range next.pieces(dst) {
+			if d := p.delta + q.delta; d != 0 {
+				ret.ents = append(ret.ents, rangeMapEnt{Interval{q.src.Lo - p.delta, q.src.Hi - p.delta}, d})
+			}
+		}
+	}
+	slices.SortFunc(ret.ents, func(a, b rangeMapEnt) int { return cmp.Compare(a.src.Lo, b.src.Lo) })
+	return ret
+}
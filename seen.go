@@ -0,0 +1,97 @@
+package aoc
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// SeenSet records which search states have been seen, keyed by a
+// user-supplied key function. Choosing the key (dropping irrelevant
+// fields, normalizing symmetric states) is often most of the work in
+// game-state searches.
+type SeenSet[S any, K comparable] struct {
+	key func(S) K
+	m   Set[K]
+}
+
+// NewSeenSet returns an empty SeenSet keying states with key.
+// For comparable states, key can simply return its argument. For
+// states with slices or maps, see HashKey.
+func NewSeenSet[S any, K comparable](key func(S) K) *SeenSet[S, K] {
+	return &SeenSet[S, K]{key: key, m: Set[K]{}}
+}
+
+// AddIfNew adds s and reports whether its key wasn't already present.
+func (ss *SeenSet[S, K]) AddIfNew(s S) bool {
+	k := ss.key(s)
+	if ss.m[k] {
+		return false
+	}
+	ss.m.Add(k)
+	return true
+}
+
+// Has reports whether s's key has been added.
+func (ss *SeenSet[S, K]) Has(s S) bool { return ss.m[ss.key(s)] }
+
+func (ss *SeenSet[S, K]) Len() int { return len(ss.m) }
+
+// HashKey returns a hash of v's %#v formatting, for use as a SeenSet
+// key for states that aren't comparable, like structs containing
+// slices or maps. (fmt prints maps in sorted key order, so equal maps
+// hash equally.) It's slow compared to a hand-written key.
+func HashKey(v any) [32]byte {
+	return sha256.Sum256(fmt.Appendf(nil, "%#v", v))
+}
+
+// StateBFS explores the state space reachable from start in
+// breadth-first order, calling visit with each new state and its
+// depth until visit returns false. States with equal keys are
+// visited once.
+func StateBFS[S any, K comparable](start S, next func(S) []S, key func(S) K, visit func(s S, depth int) (keepGoing bool)) {
+	seen := NewSeenSet(key)
+	seen.AddIfNew(start)
+	type item struct {
+		s     S
+		depth int
+	}
+	var q Deque[item]
+	q.PushBack(item{start, 0})
+	for q.Len() > 0 {
+		it := q.PopFront()
+		if !visit(it.s, it.depth) {
+			return
+		}
+		for _, n := range next(it.s) {
+			if seen.AddIfNew(n) {
+				q.PushBack(item{n, it.depth + 1})
+			}
+		}
+	}
+}
+
+// StateDFS is like StateBFS but explores depth-first.
+func StateDFS[S any, K comparable](start S, next func(S) []S, key func(S) K, visit func(s S, depth int) (keepGoing bool)) {
+	seen := NewSeenSet(key)
+	type item struct {
+		s     S
+		depth int
+	}
+	stack := []item{{start, 0}}
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !seen.AddIfNew(it.s) {
+			continue
+		}
+		if !visit(it.s, it.depth) {
+			return
+		}
+		ns := next(it.s)
+		for i := len(ns) - 1; i >= 0; i-- {
+			if !seen.Has(ns[i]) {
+				stack = append(stack, item{ns[i], it.depth + 1})
+			}
+		}
+	}
+}
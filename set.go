@@ -0,0 +1,75 @@
+package aoc
+
+import "slices"
+
+// Set is a set of T values.
+//
+// Its underlying type is map[T]bool so it interoperates with
+// existing map[T]bool code and s[v] works as a membership test.
+// Values should only ever be stored as true; use Delete to remove.
+type Set[T comparable] map[T]bool
+
+// SetOf returns a new Set containing vs.
+func SetOf[T comparable](vs ...T) Set[T] {
+	s := make(Set[T], len(vs))
+	s.Add(vs...)
+	return s
+}
+
+func (s Set[T]) Add(vs ...T) {
+	for _, v := range vs {
+		s[v] = true
+	}
+}
+
+func (s Set[T]) Has(v T) bool { return s[v] }
+func (s Set[T]) Delete(v T)   { delete(s, v) }
+func (s Set[T]) Len() int     { return len(s) }
+
+// Slice returns the set's elements in unspecified order, or a fixed
+// order if Deterministic is set.
+func (s Set[T]) Slice() []T {
+	return slices.Collect(keysOf(s))
+}
+
+func (s Set[T]) Clone() Set[T] {
+	ret := make(Set[T], len(s))
+	for v := range s {
+		ret[v] = true
+	}
+	return ret
+}
+
+// Union returns a new set of the elements in either s or o.
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	ret := s.Clone()
+	for v := range o {
+		ret[v] = true
+	}
+	return ret
+}
+
+// Intersect returns a new set of the elements in both s and o.
+func (s Set[T]) Intersect(o Set[T]) Set[T] {
+	if len(o) < len(s) {
+		s, o = o, s
+	}
+	ret := Set[T]{}
+	for v := range s {
+		if o[v] {
+			ret[v] = true
+		}
+	}
+	return ret
+}
+
+// Difference returns a new set of the elements in s but not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	ret := Set[T]{}
+	for v := range s {
+		if !o[v] {
+			ret[v] = true
+		}
+	}
+	return ret
+}
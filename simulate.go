@@ -0,0 +1,50 @@
+package aoc
+
+// Simulate repeatedly applies step, starting from state, until step
+// reports false. It returns the final state and the number of steps
+// taken.
+//
+// Each hook, if any, is called after every step with the step number
+// (starting at 1) and the new state, for drawing or recording.
+func Simulate[S any](state S, step func(S) (next S, keepGoing bool), hooks ...func(n int, s S)) (final S, steps int) {
+	for {
+		next, keepGoing := step(state)
+		steps++
+		state = next
+		for _, h := range hooks {
+			h(steps, state)
+		}
+		if !keepGoing {
+			return state, steps
+		}
+	}
+}
+
+// RunN applies step n times, starting from state, and returns the
+// final state. Hooks are as for Simulate.
+func RunN[S any](state S, n int, step func(S) S, hooks ...func(n int, s S)) S {
+	for i := 1; i <= n; i++ {
+		state = step(state)
+		for _, h := range hooks {
+			h(i, state)
+		}
+	}
+	return state
+}
+
+// RunUntilStable applies step, starting from state, until a step
+// returns a state equal to its input. It returns that state and the
+// number of steps taken, including the final unchanged one. Hooks are
+// as for Simulate.
+func RunUntilStable[S comparable](state S, step func(S) S, hooks ...func(n int, s S)) (final S, steps int) {
+	return RunUntilStableFunc(state, step, func(a, b S) bool { return a == b }, hooks...)
+}
+
+// RunUntilStableFunc is like RunUntilStable for states that aren't
+// comparable with ==, such as Grids, using equal instead.
+func RunUntilStableFunc[S any](state S, step func(S) S, equal func(a, b S) bool, hooks ...func(n int, s S)) (final S, steps int) {
+	return Simulate(state, func(s S) (S, bool) {
+		next := step(s)
+		return next, !equal(s, next)
+	}, hooks...)
+}
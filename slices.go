@@ -0,0 +1,408 @@
+package aoc
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number is any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Sum returns the sum of s.
+func Sum[T Number](s []T) T {
+	var sum T
+	for _, v := range s {
+		sum += v
+	}
+	return sum
+}
+
+// Product returns the product of s, or 1 if s is empty.
+func Product[T Number](s []T) T {
+	p := T(1)
+	for _, v := range s {
+		p *= v
+	}
+	return p
+}
+
+// SumFunc returns the sum of f applied to each element of s.
+func SumFunc[E any, T Number](s []E, f func(E) T) T {
+	var sum T
+	for _, v := range s {
+		sum += f(v)
+	}
+	return sum
+}
+
+// MaxOf returns the largest of vs, which must be non-empty.
+// Pass a slice as MaxOf(s...).
+func MaxOf[T cmp.Ordered](vs ...T) T {
+	if len(vs) == 0 {
+		panic("MaxOf of nothing")
+	}
+	m := vs[0]
+	for _, v := range vs[1:] {
+		m = max(m, v)
+	}
+	return m
+}
+
+// MinOf returns the smallest of vs, which must be non-empty.
+// Pass a slice as MinOf(s...).
+func MinOf[T cmp.Ordered](vs ...T) T {
+	if len(vs) == 0 {
+		panic("MinOf of nothing")
+	}
+	m := vs[0]
+	for _, v := range vs[1:] {
+		m = min(m, v)
+	}
+	return m
+}
+
+// MinMax returns the smallest and largest elements of s in one pass.
+// It panics if s is empty.
+func MinMax[T cmp.Ordered](s []T) (lo, hi T) {
+	if len(s) == 0 {
+		panic("MinMax of empty slice")
+	}
+	lo, hi = s[0], s[0]
+	for _, v := range s[1:] {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	return lo, hi
+}
+
+// Transpose returns the transpose of rows, so ret[x][y] is
+// rows[y][x]. Short rows are padded with the zero T.
+func Transpose[T any](rows [][]T) [][]T {
+	w := 0
+	for _, r := range rows {
+		w = max(w, len(r))
+	}
+	ret := make([][]T, w)
+	for x := range ret {
+		ret[x] = make([]T, len(rows))
+		for y, r := range rows {
+			if x < len(r) {
+				ret[x][y] = r[x]
+			}
+		}
+	}
+	return ret
+}
+
+// MapSlice returns a new slice of f applied to each element of s.
+func MapSlice[T, R any](s []T, f func(T) R) []R {
+	ret := make([]R, len(s))
+	for i, v := range s {
+		ret[i] = f(v)
+	}
+	return ret
+}
+
+// Filter returns a new slice of the elements of s for which keep
+// returns true.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var ret []T
+	for _, v := range s {
+		if keep(v) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Reduce folds s into a single value by applying f to an accumulator,
+// starting with init, and each element in order.
+func Reduce[T, A any](s []T, init A, f func(acc A, v T) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// SortBy stably sorts s in place by ascending key. Each element's key
+// is computed once.
+func SortBy[T any, K cmp.Ordered](s []T, key func(T) K) {
+	sortByKey(s, key, false)
+}
+
+// SortByDesc stably sorts s in place by descending key.
+func SortByDesc[T any, K cmp.Ordered](s []T, key func(T) K) {
+	sortByKey(s, key, true)
+}
+
+// SortedBy returns a copy of s stably sorted by ascending key.
+func SortedBy[T any, K cmp.Ordered](s []T, key func(T) K) []T {
+	s = slices.Clone(s)
+	sortByKey(s, key, false)
+	return s
+}
+
+// SortedByDesc returns a copy of s stably sorted by descending key.
+func SortedByDesc[T any, K cmp.Ordered](s []T, key func(T) K) []T {
+	s = slices.Clone(s)
+	sortByKey(s, key, true)
+	return s
+}
+
+func sortByKey[T any, K cmp.Ordered](s []T, key func(T) K, desc bool) {
+	type keyed struct {
+		k K
+		v T
+	}
+	ks := make([]keyed, len(s))
+	for i, v := range s {
+		ks[i] = keyed{key(v), v}
+	}
+	slices.SortStableFunc(ks, func(a, b keyed) int {
+		if desc {
+			return cmp.Compare(b.k, a.k)
+		}
+		return cmp.Compare(a.k, b.k)
+	})
+	for i, kv := range ks {
+		s[i] = kv.v
+	}
+}
+
+// Chunk splits s into consecutive groups of n elements. The last
+// group is shorter if len(s) isn't a multiple of n. The groups share
+// s's backing array.
+func Chunk[T any](s []T, n int) [][]T {
+	if n <= 0 {
+		panic("Chunk size must be positive")
+	}
+	ret := make([][]T, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		ret = append(ret, s[i:end:end])
+	}
+	return ret
+}
+
+// Windows returns every run of n consecutive elements of s, in
+// order. The windows share s's backing array. It returns nil if n is
+// out of range.
+func Windows[T any](s []T, n int) [][]T {
+	if n <= 0 || n > len(s) {
+		return nil
+	}
+	ret := make([][]T, 0, len(s)-n+1)
+	for i := 0; i+n <= len(s); i++ {
+		ret = append(ret, s[i:i+n:i+n])
+	}
+	return ret
+}
+
+// Pair is two values, as returned by Zip.
+type Pair[A, B any] struct {
+	A A
+	B B
+}
+
+// Zip pairs up the elements of a and b by index. The result has the
+// length of the shorter slice.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	return ZipWith(func(x A, y B) Pair[A, B] { return Pair[A, B]{x, y} }, a, b)
+}
+
+// ZipWith returns f applied to the elements of a and b pairwise. The
+// result has the length of the shorter slice.
+func ZipWith[A, B, R any](f func(A, B) R, a []A, b []B) []R {
+	n := min(len(a), len(b))
+	ret := make([]R, n)
+	for i := range ret {
+		ret[i] = f(a[i], b[i])
+	}
+	return ret
+}
+
+// ArgMax returns the index and value of the element of s with the
+// highest score, preferring the earliest on ties. It returns -1 if s
+// is empty.
+func ArgMax[T any](s []T, score func(T) int) (int, T) {
+	return argBest(s, score, func(a, b int) bool { return a > b })
+}
+
+// ArgMin returns the index and value of the element of s with the
+// lowest score, preferring the earliest on ties. It returns -1 if s
+// is empty.
+func ArgMin[T any](s []T, score func(T) int) (int, T) {
+	return argBest(s, score, func(a, b int) bool { return a < b })
+}
+
+func argBest[T any](s []T, score func(T) int, better func(a, b int) bool) (int, T) {
+	if len(s) == 0 {
+		var zero T
+		return -1, zero
+	}
+	bi, bs := 0, score(s[0])
+	for i, v := range s[1:] {
+		if sc := score(v); better(sc, bs) {
+			bi, bs = i+1, sc
+		}
+	}
+	return bi, s[bi]
+}
+
+// TopK returns the k elements of s with the highest scores, highest
+// first, preferring earlier elements on ties. It keeps only k
+// candidates in a heap rather than sorting all of s.
+func TopK[T any](s []T, k int, score func(T) int) []T {
+	if k <= 0 {
+		return nil
+	}
+	h := make(topKHeap[T], 0, min(k, len(s)))
+	for i, v := range s {
+		c := topKItem[T]{v, score(v), i}
+		if len(h) < k {
+			heap.Push(&h, c)
+		} else if h.less(h[0], c) {
+			h[0] = c
+			heap.Fix(&h, 0)
+		}
+	}
+	slices.SortFunc(h, func(a, b topKItem[T]) int {
+		return cmp.Or(cmp.Compare(b.score, a.score), cmp.Compare(a.idx, b.idx))
+	})
+	ret := make([]T, len(h))
+	for i, c := range h {
+		ret[i] = c.v
+	}
+	return ret
+}
+
+type topKItem[T any] struct {
+	v     T
+	score int
+	idx   int
+}
+
+// topKHeap is a min-heap of the best candidates so far, with the
+// worst (lowest score, latest on ties) at the root.
+type topKHeap[T any] []topKItem[T]
+
+func (topKHeap[T]) less(a, b topKItem[T]) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	return a.idx > b.idx
+}
+
+func (h topKHeap[T]) Len() int           { return len(h) }
+func (h topKHeap[T]) Less(i, j int) bool { return h.less(h[i], h[j]) }
+func (h topKHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *topKHeap[T]) Push(x any)        { *h = append(*h, x.(topKItem[T])) }
+func (h *topKHeap[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// Reverse reverses s in place.
+func Reverse[T any](s []T) { slices.Reverse(s) }
+
+// Reversed returns a reversed copy of s, leaving s unmodified.
+func Reversed[T any](s []T) []T {
+	ret := make([]T, len(s))
+	for i, v := range s {
+		ret[len(s)-1-i] = v
+	}
+	return ret
+}
+
+// Uniq returns the distinct elements of s in order of first
+// occurrence.
+func Uniq[T comparable](s []T) []T {
+	return UniqBy(s, func(v T) T { return v })
+}
+
+// UniqBy returns the elements of s with distinct keys, keeping the
+// first element seen for each key, in order.
+func UniqBy[T any, K comparable](s []T, key func(T) K) []T {
+	seen := Set[K]{}
+	var ret []T
+	for _, v := range s {
+		k := key(v)
+		if !seen[k] {
+			seen.Add(k)
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Any reports whether pred returns true for any element of s.
+func Any[T any](s []T, pred func(T) bool) bool {
+	return IndexWhere(s, pred) >= 0
+}
+
+// All reports whether pred returns true for every element of s.
+func All[T any](s []T, pred func(T) bool) bool {
+	for _, v := range s {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// CountWhere returns how many elements of s pred returns true for.
+func CountWhere[T any](s []T, pred func(T) bool) int {
+	n := 0
+	for _, v := range s {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// IndexWhere returns the index of the first element of s for which
+// pred returns true, or -1 if none.
+func IndexWhere[T any](s []T, pred func(T) bool) int {
+	for i, v := range s {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GroupBy buckets the elements of s by key, keeping their order
+// within each bucket.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	m := map[K][]T{}
+	for _, v := range s {
+		k := key(v)
+		m[k] = append(m[k], v)
+	}
+	return m
+}
+
+// RotateSlice rotates s in place right by n elements, so [a b c d]
+// rotated by 1 is [d a b c]. Negative n rotates left. n may exceed
+// len(s).
+func RotateSlice[T any](s []T, n int) {
+	if len(s) == 0 {
+		return
+	}
+	n %= len(s)
+	if n < 0 {
+		n += len(s)
+	}
+	slices.Reverse(s)
+	slices.Reverse(s[:n])
+	slices.Reverse(s[n:])
+}
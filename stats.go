@@ -0,0 +1,49 @@
+package aoc
+
+import "slices"
+
+// Mean returns the arithmetic mean of s, or NaN if s is empty.
+// The crab-alignment fuel minimum for triangular costs is within 0.5
+// of it.
+func Mean[T Number](s []T) float64 {
+	var sum float64
+	for _, v := range s {
+		sum += float64(v)
+	}
+	return sum / float64(len(s))
+}
+
+// Median returns the middle element of s in sorted order. For even
+// lengths it returns the lower of the two middle elements, which for
+// integers still minimizes the sum of absolute deviations. It panics
+// if s is empty. s is not modified.
+func Median[T Number](s []T) T {
+	if len(s) == 0 {
+		panic("Median of empty slice")
+	}
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
+
+// Mode returns the most common element of s and its count, preferring
+// the smallest value on ties. It panics if s is empty.
+func Mode[T Number](s []T) (v T, n int) {
+	if len(s) == 0 {
+		panic("Mode of empty slice")
+	}
+	c := Frequencies(s)
+	for k, cnt := range c {
+		if cnt > n || cnt == n && k < v {
+			v, n = k, cnt
+		}
+	}
+	return v, n
+}
+
+// MinMaxRange returns the smallest and largest elements of s and the
+// distance hi-lo between them. It panics if s is empty.
+func MinMaxRange[T Number](s []T) (lo, hi, span T) {
+	lo, hi = MinMax(s)
+	return lo, hi, hi - lo
+}
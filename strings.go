@@ -0,0 +1,256 @@
+package aoc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ReverseString returns s with its runes in reverse order.
+func ReverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// RotateString rotates s right by n runes, so "abcd" rotated by 1 is
+// "dabc". Negative n rotates left. n may exceed the length of s.
+func RotateString(s string, n int) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	n %= len(r)
+	if n < 0 {
+		n += len(r)
+	}
+	return string(r[len(r)-n:]) + string(r[:len(r)-n])
+}
+
+// SwapPositions returns s with the runes at indexes i and j swapped.
+func SwapPositions(s string, i, j int) string {
+	r := []rune(s)
+	r[i], r[j] = r[j], r[i]
+	return string(r)
+}
+
+// SwapLetters returns s with every a replaced by b and vice versa.
+func SwapLetters(s string, a, b rune) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case a:
+			return b
+		case b:
+			return a
+		}
+		return r
+	}, s)
+}
+
+// Run is a run of N consecutive copies of a rune.
+type Run struct {
+	Rune rune
+	N    int
+}
+
+// RunLengths returns the run-length encoding of s.
+func RunLengths(s string) []Run {
+	var runs []Run
+	for _, r := range s {
+		if n := len(runs); n > 0 && runs[n-1].Rune == r {
+			runs[n-1].N++
+			continue
+		}
+		runs = append(runs, Run{r, 1})
+	}
+	return runs
+}
+
+// LookAndSay returns the next term of the look-and-say sequence
+// after s: "1211" becomes "111221".
+func LookAndSay(s string) string {
+	var sb strings.Builder
+	for _, run := range RunLengths(s) {
+		sb.WriteString(strconv.Itoa(run.N))
+		sb.WriteRune(run.Rune)
+	}
+	return sb.String()
+}
+
+// TransposeStrings returns the columns of lines as strings, so
+// column x of the input is element x of the result. Short lines are
+// padded with spaces.
+func TransposeStrings(lines []string) []string {
+	rows := make([][]rune, len(lines))
+	for i, l := range lines {
+		rows[i] = []rune(l)
+	}
+	cols := Transpose(rows)
+	ret := make([]string, len(cols))
+	for i, c := range cols {
+		for j, r := range c {
+			if r == 0 {
+				c[j] = ' '
+			}
+		}
+		ret[i] = string(c)
+	}
+	return ret
+}
+
+// HammingDistance returns the number of byte positions at which a and
+// b differ. If one is longer, each extra byte counts as a difference.
+func HammingDistance(a, b string) int {
+	n := AbsDiff(len(a), len(b))
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if a[i] != b[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// HammingDistanceBytes is like HammingDistance for byte slices.
+func HammingDistanceBytes(a, b []byte) int {
+	n := AbsDiff(len(a), len(b))
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if a[i] != b[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// FindAllIndexes returns the byte index of every occurrence of needle
+// in haystack, including overlapping ones: "aa" occurs in "aaa" at 0
+// and 1.
+func FindAllIndexes(haystack, needle string) []int {
+	if needle == "" {
+		return nil
+	}
+	var idx []int
+	for off := 0; ; {
+		i := strings.Index(haystack[off:], needle)
+		if i < 0 {
+			return idx
+		}
+		idx = append(idx, off+i)
+		off += i + 1
+	}
+}
+
+// CountOccurrences returns how many times needle occurs in haystack.
+// If overlapping is false, it's like strings.Count (except that an
+// empty needle occurs zero times).
+func CountOccurrences(haystack, needle string, overlapping bool) int {
+	if needle == "" {
+		return 0
+	}
+	if !overlapping {
+		return strings.Count(haystack, needle)
+	}
+	return len(FindAllIndexes(haystack, needle))
+}
+
+// LongestCommonPrefix returns the longest string that all of strs
+// start with. Pass a slice as LongestCommonPrefix(s...).
+func LongestCommonPrefix(strs ...string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	p := strs[0]
+	for _, s := range strs[1:] {
+		n := 0
+		for n < len(p) && n < len(s) && p[n] == s[n] {
+			n++
+		}
+		p = p[:n]
+	}
+	return p
+}
+
+// LongestCommonSuffix returns the longest string that all of strs
+// end with. Pass a slice as LongestCommonSuffix(s...).
+func LongestCommonSuffix(strs ...string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	p := strs[0]
+	for _, s := range strs[1:] {
+		n := 0
+		for n < len(p) && n < len(s) && p[len(p)-1-n] == s[len(s)-1-n] {
+			n++
+		}
+		p = p[len(p)-n:]
+	}
+	return p
+}
+
+// LongestCommonSubstring returns the longest contiguous string that
+// occurs in both a and b, preferring the earliest in a on ties.
+func LongestCommonSubstring(a, b string) string {
+	// prev[j] is the length of the common suffix of a[:i-1] and b[:j].
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	bestLen, bestEnd := 0, 0
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				cur[j] = prev[j-1] + 1
+				if cur[j] > bestLen {
+					bestLen, bestEnd = cur[j], i
+				}
+			} else {
+				cur[j] = 0
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return a[bestEnd-bestLen : bestEnd]
+}
+
+// LetterIndex returns the 0-based position of the ASCII letter r in
+// the alphabet, ignoring case: 'a' and 'A' are 0, 'z' is 25. It
+// returns -1 if r isn't a letter. Add 1 for 1-based numbering.
+func LetterIndex(r rune) int {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return int(r - 'a')
+	case r >= 'A' && r <= 'Z':
+		return int(r - 'A')
+	}
+	return -1
+}
+
+// LetterPriority returns the rucksack priority of r: 1 through 26 for
+// 'a' through 'z' and 27 through 52 for 'A' through 'Z'. It returns 0
+// for anything else.
+func LetterPriority(r rune) int {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return int(r-'a') + 1
+	case r >= 'A' && r <= 'Z':
+		return int(r-'A') + 27
+	}
+	return 0
+}
+
+// ShiftLetter returns the ASCII letter r moved n places along the
+// alphabet, wrapping around and keeping its case. Negative n shifts
+// backwards. Other runes are returned unchanged.
+func ShiftLetter(r rune, n int) rune {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 'a' + rune(Mod(int(r-'a')+n, 26))
+	case r >= 'A' && r <= 'Z':
+		return 'A' + rune(Mod(int(r-'A')+n, 26))
+	}
+	return r
+}
+
+// CaesarShift returns s with each letter shifted n places using
+// ShiftLetter. Use -n to decrypt.
+func CaesarShift(s string, n int) string {
+	return strings.Map(func(r rune) rune { return ShiftLetter(r, n) }, s)
+}
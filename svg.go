@@ -0,0 +1,79 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// SVGOptions configures WriteSVG.
+type SVGOptions struct {
+	// CellSize is the width and height of each cell in pixels.
+	// Zero means 10.
+	CellSize int
+
+	// Colors maps cell values to SVG fill colors. Cells with values
+	// not in Colors are drawn in DefaultColor, or not at all if
+	// DefaultColor is empty.
+	Colors       map[rune]string
+	DefaultColor string
+
+	// Highlight cells are drawn over the grid in HighlightColor
+	// (default "red").
+	Highlight      Set[Pt]
+	HighlightColor string
+
+	// Path, if non-empty, is drawn as a line through the centers of
+	// its cells in PathColor (default "blue").
+	Path      []Pt
+	PathColor string
+}
+
+// WriteSVG renders g as an SVG image. opts may be nil.
+func WriteSVG(w io.Writer, g Grid, opts *SVGOptions) error {
+	var o SVGOptions
+	if opts != nil {
+		o = *opts
+	}
+	cs := Or(o.CellSize, 10)
+	minX, minY, maxX, maxY := g.Bounds()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" shape-rendering="crispEdges">`+"\n",
+		(maxX-minX+1)*cs, (maxY-minY+1)*cs)
+	rect := func(p Pt, color string) {
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			(p.X-minX)*cs, (p.Y-minY)*cs, cs, cs, color)
+	}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			p := Pt{x, y}
+			r, ok := g[p]
+			if !ok {
+				continue
+			}
+			if c := Or(o.Colors[r], o.DefaultColor); c != "" {
+				rect(p, c)
+			}
+		}
+	}
+	hc := Or(o.HighlightColor, "red")
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			if p := (Pt{x, y}); o.Highlight[p] {
+				rect(p, hc)
+			}
+		}
+	}
+	if len(o.Path) > 0 {
+		fmt.Fprintf(bw, `<polyline fill="none" stroke="%s" stroke-width="%d" points="`, Or(o.PathColor, "blue"), max(1, cs/4))
+		for i, p := range o.Path {
+			if i > 0 {
+				bw.WriteByte(' ')
+			}
+			fmt.Fprintf(bw, "%d,%d", (p.X-minX)*cs+cs/2, (p.Y-minY)*cs+cs/2)
+		}
+		fmt.Fprintf(bw, "\"/>\n")
+	}
+	fmt.Fprintf(bw, "</svg>\n")
+	return bw.Flush()
+}
@@ -0,0 +1,75 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// PrintTable prints rows as an aligned table to stdout, with a
+// header row and separator. Cells are formatted with fmt.Sprint;
+// numbers are right-aligned.
+func PrintTable(headers []string, rows [][]any) {
+	FprintTable(os.Stdout, headers, rows)
+}
+
+// FprintTable is like PrintTable but writes to w.
+func FprintTable(w io.Writer, headers []string, rows [][]any) {
+	ncol := len(headers)
+	for _, r := range rows {
+		ncol = max(ncol, len(r))
+	}
+	widths := make([]int, ncol)
+	cells := make([][]string, len(rows))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for i, r := range rows {
+		cells[i] = make([]string, len(r))
+		for j, v := range r {
+			s := fmt.Sprint(v)
+			cells[i][j] = s
+			widths[j] = max(widths[j], utf8.RuneCountInString(s))
+		}
+	}
+	pad := func(s string, width int, right bool) string {
+		sp := strings.Repeat(" ", width-utf8.RuneCountInString(s))
+		if right {
+			return sp + s
+		}
+		return s + sp
+	}
+	line := func(cols []string, right func(j int) bool) {
+		var sb strings.Builder
+		for j, s := range cols {
+			if j > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(pad(s, widths[j], right(j)))
+		}
+		fmt.Fprintln(w, strings.TrimRight(sb.String(), " "))
+	}
+	if len(headers) > 0 {
+		line(headers, func(int) bool { return false })
+		seps := make([]string, len(headers))
+		for j := range seps {
+			seps[j] = strings.Repeat("-", widths[j])
+		}
+		line(seps, func(int) bool { return false })
+	}
+	for i, r := range rows {
+		line(cells[i], func(j int) bool { return isNumber(r[j]) })
+	}
+}
+
+func isNumber(v any) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Timer starts timing a phase of a puzzle, such as parsing or part 2,
+// and returns a func that prints how long it took to stderr. Use it
+// as:
+//
+//	defer aoc.Timer("part 2")()
+//
+// or call the returned func at the end of the phase.
+func Timer(phase string) func() {
+	t0 := time.Now()
+	return func() {
+		mode := ""
+		if altInput != nil {
+			mode = " (sample)"
+		}
+		fmt.Fprintf(os.Stderr, "⏱️ %s%s took %v\n", phase, mode, time.Since(t0).Round(time.Microsecond))
+	}
+}
@@ -0,0 +1,122 @@
+package aoc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Instr is one instruction of an assembly program.
+type Instr struct {
+	Op   string
+	Args []string
+}
+
+func (in Instr) String() string {
+	return strings.TrimSpace(in.Op + " " + strings.Join(in.Args, " "))
+}
+
+// ParseProgram parses src as one instruction per line, with the
+// opcode and arguments separated by spaces and/or commas. Blank lines
+// are skipped.
+func ParseProgram(src string) []Instr {
+	var prog []Instr
+	for _, line := range strings.Split(src, "\n") {
+		f := strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		if len(f) == 0 {
+			continue
+		}
+		prog = append(prog, Instr{Op: f[0], Args: f[1:]})
+	}
+	return prog
+}
+
+// Machine is a register machine that runs a program of Instrs with
+// pluggable opcode handlers, as in the assembunny and handheld game
+// console puzzles.
+type Machine struct {
+	Prog []Instr
+	Regs map[string]int
+	PC   int
+
+	// Ops maps opcodes to their handlers. After a handler returns,
+	// the PC advances by one unless the handler called Jump or Halt.
+	Ops map[string]func(m *Machine, args []string)
+
+	// Trace, if non-nil, is called before each instruction runs.
+	Trace func(m *Machine, in Instr)
+
+	// Break, if non-nil, is called before each instruction runs. If
+	// it returns true, Run returns without running the instruction.
+	Break func(m *Machine) bool
+
+	Steps int // instructions run so far
+
+	jumped, halted bool
+}
+
+// NewMachine returns a Machine for prog with empty registers and the
+// given opcode handlers.
+func NewMachine(prog []Instr, ops map[string]func(m *Machine, args []string)) *Machine {
+	return &Machine{Prog: prog, Regs: map[string]int{}, Ops: ops}
+}
+
+// Val returns the value of arg, which is either an integer literal or
+// a register name.
+func (m *Machine) Val(arg string) int {
+	if v, err := strconv.Atoi(arg); err == nil {
+		return v
+	}
+	return m.Regs[arg]
+}
+
+// Set sets register reg to v.
+func (m *Machine) Set(reg string, v int) { m.Regs[reg] = v }
+
+// Jump moves the PC by off relative to the current instruction.
+func (m *Machine) Jump(off int) {
+	m.PC += off
+	m.jumped = true
+}
+
+// Halt stops the machine after the current instruction.
+func (m *Machine) Halt() { m.halted = true }
+
+// Halted reports whether the PC has left the program or Halt was
+// called.
+func (m *Machine) Halted() bool {
+	return m.halted || m.PC < 0 || m.PC >= len(m.Prog)
+}
+
+// Step runs one instruction, unless the machine is halted.
+func (m *Machine) Step() {
+	if m.Halted() {
+		return
+	}
+	in := m.Prog[m.PC]
+	if m.Trace != nil {
+		m.Trace(m, in)
+	}
+	op, ok := m.Ops[in.Op]
+	if !ok {
+		panic(fmt.Sprintf("pc %d: unknown op %q", m.PC, in.Op))
+	}
+	m.jumped = false
+	op(m, in.Args)
+	m.Steps++
+	if !m.jumped && !m.halted {
+		m.PC++
+	}
+}
+
+// Run runs until the machine halts or Break returns true.
+// It reports whether the machine halted.
+func (m *Machine) Run() (halted bool) {
+	for !m.Halted() {
+		if m.Break != nil && m.Break(m) {
+			return false
+		}
+		m.Step()
+	}
+	return true
+}
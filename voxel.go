@@ -0,0 +1,77 @@
+package aoc
+
+// Neighbors6 returns the six points sharing a face with p.
+func (p Pt3[T]) Neighbors6() [6]Pt3[T] {
+	return [6]Pt3[T]{
+		{p.X + 1, p.Y, p.Z}, {p.X - 1, p.Y, p.Z},
+		{p.X, p.Y + 1, p.Z}, {p.X, p.Y - 1, p.Z},
+		{p.X, p.Y, p.Z + 1}, {p.X, p.Y, p.Z - 1},
+	}
+}
+
+// VoxelSet is a set of occupied voxels.
+type VoxelSet map[Vox]bool
+
+func (s VoxelSet) Add(v Vox)      { s[v] = true }
+func (s VoxelSet) Has(v Vox) bool { return s[v] }
+
+// Bounds returns the minimum and maximum corners of s's bounding box.
+func (s VoxelSet) Bounds() (lo, hi Vox) {
+	first := true
+	for v := range s {
+		if first {
+			lo, hi, first = v, v, false
+			continue
+		}
+		lo = Vox{min(lo.X, v.X), min(lo.Y, v.Y), min(lo.Z, v.Z)}
+		hi = Vox{max(hi.X, v.X), max(hi.Y, v.Y), max(hi.Z, v.Z)}
+	}
+	return lo, hi
+}
+
+// SurfaceArea returns the number of voxel faces not touching another
+// voxel in s, including faces of internal air pockets.
+func (s VoxelSet) SurfaceArea() int {
+	n := 0
+	for v := range s {
+		for _, nb := range v.Neighbors6() {
+			if !s[nb] {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// ExteriorSurfaceArea is like SurfaceArea but counts only faces
+// reachable from outside, excluding enclosed air pockets. It flood
+// fills the air from just outside the bounding box.
+func (s VoxelSet) ExteriorSurfaceArea() int {
+	if len(s) == 0 {
+		return 0
+	}
+	lo, hi := s.Bounds()
+	lo = lo.Sub(Vox{1, 1, 1})
+	hi = hi.Add(Vox{1, 1, 1})
+	in := func(v Vox) bool {
+		return v.X >= lo.X && v.X <= hi.X && v.Y >= lo.Y && v.Y <= hi.Y && v.Z >= lo.Z && v.Z <= hi.Z
+	}
+	air := Set[Vox]{lo: true}
+	q := []Vox{lo}
+	n := 0
+	for len(q) > 0 {
+		v := q[len(q)-1]
+		q = q[:len(q)-1]
+		for _, nb := range v.Neighbors6() {
+			switch {
+			case !in(nb) || air[nb]:
+			case s[nb]:
+				n++ // a face seen from outside
+			default:
+				air.Add(nb)
+				q = append(q, nb)
+			}
+		}
+	}
+	return n
+}
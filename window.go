@@ -0,0 +1,56 @@
+package aoc
+
+// SlidingSum returns the sums of each window of k consecutive
+// elements of s. It returns nil if k is out of range.
+func SlidingSum(s []int, k int) []int {
+	if k <= 0 || k > len(s) {
+		return nil
+	}
+	ret := make([]int, 0, len(s)-k+1)
+	sum := 0
+	for i, v := range s {
+		sum += v
+		if i >= k {
+			sum -= s[i-k]
+		}
+		if i >= k-1 {
+			ret = append(ret, sum)
+		}
+	}
+	return ret
+}
+
+// SlidingMax returns the maximum of each window of k consecutive
+// elements of s. It returns nil if k is out of range.
+func SlidingMax(s []int, k int) []int {
+	return slidingBest(s, k, func(a, b int) bool { return a >= b })
+}
+
+// SlidingMin returns the minimum of each window of k consecutive
+// elements of s. It returns nil if k is out of range.
+func SlidingMin(s []int, k int) []int {
+	return slidingBest(s, k, func(a, b int) bool { return a <= b })
+}
+
+// slidingBest is the monotonic queue algorithm behind SlidingMax and
+// SlidingMin. better(a, b) reports whether a should evict b.
+func slidingBest(s []int, k int, better func(a, b int) bool) []int {
+	if k <= 0 || k > len(s) {
+		return nil
+	}
+	ret := make([]int, 0, len(s)-k+1)
+	var q []int // indexes into s; s values monotonic from best
+	for i, v := range s {
+		for len(q) > 0 && better(v, s[q[len(q)-1]]) {
+			q = q[:len(q)-1]
+		}
+		q = append(q, i)
+		if q[0] <= i-k {
+			q = q[1:]
+		}
+		if i >= k-1 {
+			ret = append(ret, s[q[0]])
+		}
+	}
+	return ret
+}